@@ -2,21 +2,30 @@ package cli
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
 
+	"rss-aggregator/clean-arch/entity"
 	"rss-aggregator/clean-arch/usecase"
+	"rss-aggregator/internal/opml"
 )
 
 // CLI представляет интерфейс командной строки
 type CLI struct {
-	addFeedUseCase      *usecase.AddFeedUseCase
-	listFeedsUseCase    *usecase.ListFeedsUseCase
-	fetchArticlesUseCase *usecase.FetchArticlesUseCase
-	listArticlesUseCase  *usecase.ListArticlesUseCase
-	scanner             *bufio.Scanner
+	addFeedUseCase        *usecase.AddFeedUseCase
+	listFeedsUseCase      *usecase.ListFeedsUseCase
+	fetchArticlesUseCase  *usecase.FetchArticlesUseCase
+	listArticlesUseCase   *usecase.ListArticlesUseCase
+	searchArticlesUseCase *usecase.SearchArticlesUseCase
+	importFeedsUseCase    *usecase.ImportFeedsUseCase
+	exportFeedsUseCase    *usecase.ExportFeedsUseCase
+	listCategoriesUseCase *usecase.ListCategoriesUseCase
+	categorizeFeedUseCase *usecase.CategorizeFeedUseCase
+	scanner               *bufio.Scanner
 }
 
 // NewCLI создает новый экземпляр CLI
@@ -25,13 +34,23 @@ func NewCLI(
 	listFeedsUseCase *usecase.ListFeedsUseCase,
 	fetchArticlesUseCase *usecase.FetchArticlesUseCase,
 	listArticlesUseCase *usecase.ListArticlesUseCase,
+	searchArticlesUseCase *usecase.SearchArticlesUseCase,
+	importFeedsUseCase *usecase.ImportFeedsUseCase,
+	exportFeedsUseCase *usecase.ExportFeedsUseCase,
+	listCategoriesUseCase *usecase.ListCategoriesUseCase,
+	categorizeFeedUseCase *usecase.CategorizeFeedUseCase,
 ) *CLI {
 	return &CLI{
-		addFeedUseCase:      addFeedUseCase,
-		listFeedsUseCase:    listFeedsUseCase,
-		fetchArticlesUseCase: fetchArticlesUseCase,
-		listArticlesUseCase:  listArticlesUseCase,
-		scanner:             bufio.NewScanner(os.Stdin),
+		addFeedUseCase:        addFeedUseCase,
+		listFeedsUseCase:      listFeedsUseCase,
+		fetchArticlesUseCase:  fetchArticlesUseCase,
+		listArticlesUseCase:   listArticlesUseCase,
+		searchArticlesUseCase: searchArticlesUseCase,
+		importFeedsUseCase:    importFeedsUseCase,
+		exportFeedsUseCase:    exportFeedsUseCase,
+		listCategoriesUseCase: listCategoriesUseCase,
+		categorizeFeedUseCase: categorizeFeedUseCase,
+		scanner:               bufio.NewScanner(os.Stdin),
 	}
 }
 
@@ -39,12 +58,17 @@ func NewCLI(
 func (c *CLI) Run() {
 	fmt.Println("=== RSS Aggregator ===")
 	fmt.Println("Доступные команды:")
-	fmt.Println("  add <url>          - Добавить RSS-ленту")
-	fmt.Println("  list-feeds         - Показать все RSS-ленты")
-	fmt.Println("  fetch <feed-id>    - Обновить статьи из ленты")
-	fmt.Println("  articles [feed-id] - Показать статьи (опционально для конкретной ленты)")
-	fmt.Println("  help               - Показать эту справку")
-	fmt.Println("  exit               - Выход")
+	fmt.Println("  add <url>                     - Добавить RSS-ленту")
+	fmt.Println("  list-feeds                    - Показать все RSS-ленты и их статус")
+	fmt.Println("  fetch <feed-id>               - Обновить статьи из ленты")
+	fmt.Println("  articles [feed-id] [query]    - Показать статьи (опционально для ленты и по поисковому запросу)")
+	fmt.Println("  categories                    - Показать категории и число непрочитанных статей")
+	fmt.Println("  categorize <feed-id> <name>   - Добавить ленту в категорию")
+	fmt.Println("  uncategorize <feed-id> <name> - Убрать ленту из категории")
+	fmt.Println("  import-opml <file>            - Импортировать ленты из OPML-файла")
+	fmt.Println("  export-opml <file>            - Экспортировать ленты в OPML-файл")
+	fmt.Println("  help                          - Показать эту справку")
+	fmt.Println("  exit                          - Выход")
 	fmt.Println()
 
 	for {
@@ -89,17 +113,55 @@ func (c *CLI) Run() {
 			c.handleFetchArticles(feedID)
 
 		case "articles":
+			// Первый аргумент - ID ленты, если это число; остальное - поисковый запрос
 			feedID := 0
-			if len(parts) >= 2 {
-				var err error
-				feedID, err = strconv.Atoi(parts[1])
-				if err != nil {
-					fmt.Printf("Ошибка: неверный ID ленты: %v\n", err)
-					continue
+			args := parts[1:]
+			if len(args) > 0 {
+				if id, err := strconv.Atoi(args[0]); err == nil {
+					feedID = id
+					args = args[1:]
 				}
 			}
+			if len(args) > 0 {
+				c.handleSearchArticles(strings.Join(args, " "), feedID)
+				continue
+			}
 			c.handleListArticles(feedID)
 
+		case "categories":
+			c.handleListCategories()
+
+		case "categorize", "uncategorize":
+			if len(parts) < 3 {
+				fmt.Println("Ошибка: укажите ID ленты и название категории")
+				continue
+			}
+			feedID, err := strconv.Atoi(parts[1])
+			if err != nil {
+				fmt.Printf("Ошибка: неверный ID ленты: %v\n", err)
+				continue
+			}
+			name := strings.Join(parts[2:], " ")
+			if command == "categorize" {
+				c.handleCategorize(feedID, name)
+			} else {
+				c.handleUncategorize(feedID, name)
+			}
+
+		case "import-opml":
+			if len(parts) < 2 {
+				fmt.Println("Ошибка: укажите путь к OPML-файлу")
+				continue
+			}
+			c.handleImportOPML(parts[1])
+
+		case "export-opml":
+			if len(parts) < 2 {
+				fmt.Println("Ошибка: укажите путь к OPML-файлу")
+				continue
+			}
+			c.handleExportOPML(parts[1])
+
 		case "help":
 			c.printHelp()
 
@@ -113,8 +175,17 @@ func (c *CLI) Run() {
 	}
 }
 
+// commandContext возвращает контекст выполнения команды, который отменяется
+// по Ctrl+C, чтобы прервать долгую загрузку ленты, не завершая CLI
+func commandContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
 func (c *CLI) handleAddFeed(url string) {
-	feed, err := c.addFeedUseCase.Execute(url)
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	feed, result, err := c.addFeedUseCase.Execute(ctx, url)
 	if err != nil {
 		fmt.Printf("Ошибка: %v\n", err)
 		return
@@ -127,11 +198,16 @@ func (c *CLI) handleAddFeed(url string) {
 	if feed.Description != "" {
 		fmt.Printf("  Описание: %s\n", feed.Description)
 	}
+	fmt.Printf("  Статей: %d\n", result.Created)
+	printArticleErrors(result)
 	fmt.Println()
 }
 
 func (c *CLI) handleListFeeds() {
-	feeds, err := c.listFeedsUseCase.Execute()
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	feeds, err := c.listFeedsUseCase.Execute(ctx)
 	if err != nil {
 		fmt.Printf("Ошибка: %v\n", err)
 		return
@@ -150,23 +226,60 @@ func (c *CLI) handleListFeeds() {
 		if feed.Description != "" {
 			fmt.Printf("      Описание: %s\n", feed.Description)
 		}
+		fmt.Printf("      Статус: %s\n", formatFeedHealth(feed.Health))
 		fmt.Println()
 	}
 }
 
+// formatFeedHealth описывает состояние ленты одной строкой: статус и, если
+// загрузки не удаются, число неудач подряд, HTTP-статус и ошибку
+func formatFeedHealth(health entity.FeedHealth) string {
+	status := health.Status()
+	if health.ConsecutiveFailures == 0 {
+		return status
+	}
+
+	details := []string{fmt.Sprintf("неудач подряд: %d", health.ConsecutiveFailures)}
+	if health.LastHTTPStatus != 0 {
+		details = append(details, fmt.Sprintf("HTTP %d", health.LastHTTPStatus))
+	}
+	if health.LastError != "" {
+		details = append(details, "ошибка: "+health.LastError)
+	}
+	return fmt.Sprintf("%s (%s)", status, strings.Join(details, ", "))
+}
+
 func (c *CLI) handleFetchArticles(feedID int) {
-	err := c.fetchArticlesUseCase.Execute(feedID)
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	parsedFeed, result, err := c.fetchArticlesUseCase.Execute(ctx, feedID)
 	if err != nil {
 		fmt.Printf("Ошибка: %v\n", err)
 		return
 	}
+	if parsedFeed.NotModified {
+		fmt.Printf("✓ Лента %d не изменилась с прошлой загрузки\n\n", feedID)
+		return
+	}
 
-	fmt.Printf("✓ Статьи из ленты %d обновлены\n", feedID)
+	fmt.Printf("✓ Статьи из ленты %d обновлены: новых %d, обновлено %d\n", feedID, result.Created, result.Updated)
+	printArticleErrors(result)
 	fmt.Println()
 }
 
+// printArticleErrors выводит статьи, которые не удалось сохранить
+func printArticleErrors(result entity.UpsertResult) {
+	for _, articleErr := range result.Errors {
+		fmt.Printf("  ✗ Не удалось сохранить статью: %v\n", articleErr)
+	}
+}
+
 func (c *CLI) handleListArticles(feedID int) {
-	articles, err := c.listArticlesUseCase.Execute(feedID)
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	articles, err := c.listArticlesUseCase.Execute(ctx, feedID)
 	if err != nil {
 		fmt.Printf("Ошибка: %v\n", err)
 		return
@@ -188,6 +301,163 @@ func (c *CLI) handleListArticles(feedID int) {
 		fmt.Println("Все статьи:")
 	}
 
+	printArticles(articles)
+}
+
+func (c *CLI) handleSearchArticles(query string, feedID int) {
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	articles, err := c.searchArticlesUseCase.Execute(ctx, query, feedID)
+	if err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+
+	if len(articles) == 0 {
+		fmt.Printf("По запросу %q ничего не найдено\n", query)
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("Найдено статей по запросу %q: %d\n", query, len(articles))
+	printArticles(articles)
+}
+
+func (c *CLI) handleListCategories() {
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	categories, err := c.listCategoriesUseCase.Execute(ctx)
+	if err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+
+	if len(categories) == 0 {
+		fmt.Println("Нет категорий")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("Категории:")
+	for _, category := range categories {
+		fmt.Printf("  [%d] %s - лент: %d, непрочитанных: %d\n", category.ID, category.Name, len(category.FeedIDs), category.UnreadCount)
+	}
+	fmt.Println()
+}
+
+func (c *CLI) handleCategorize(feedID int, name string) {
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	category, err := c.categorizeFeedUseCase.Assign(ctx, feedID, name)
+	if err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+
+	fmt.Printf("✓ Лента %d добавлена в категорию %s\n", feedID, category.Name)
+	fmt.Println()
+}
+
+func (c *CLI) handleUncategorize(feedID int, name string) {
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	if err := c.categorizeFeedUseCase.Unassign(ctx, feedID, name); err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+
+	fmt.Printf("✓ Лента %d убрана из категории %s\n", feedID, name)
+	fmt.Println()
+}
+
+func (c *CLI) handleImportOPML(path string) {
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+	defer file.Close()
+
+	parsed, err := opml.Parse(file)
+	if err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+
+	subscriptions := make([]entity.Subscription, 0, len(parsed))
+	for _, subscription := range parsed {
+		subscriptions = append(subscriptions, entity.Subscription{
+			URL:        subscription.URL,
+			Title:      subscription.Title,
+			Categories: subscription.Categories,
+		})
+	}
+
+	var added, existing, failed int
+	for _, result := range c.importFeedsUseCase.Execute(ctx, subscriptions) {
+		switch result.Status {
+		case entity.ImportStatusAdded:
+			added++
+			fmt.Printf("  ✓ %s\n", result.URL)
+		case entity.ImportStatusExists:
+			existing++
+			fmt.Printf("  = %s (уже добавлена)\n", result.URL)
+		case entity.ImportStatusFailed:
+			failed++
+			fmt.Printf("  ✗ %s: %v\n", result.URL, result.Err)
+		}
+	}
+	fmt.Printf("Импорт завершен: добавлено %d, уже были %d, ошибок %d\n", added, existing, failed)
+	fmt.Println()
+}
+
+func (c *CLI) handleExportOPML(path string) {
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	subscriptions, err := c.exportFeedsUseCase.Execute(ctx)
+	if err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+
+	converted := make([]opml.Subscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		converted = append(converted, opml.Subscription{
+			URL:        subscription.URL,
+			Title:      subscription.Title,
+			Categories: subscription.Categories,
+		})
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+	if err := opml.Write(file, "RSS Aggregator subscriptions", converted); err != nil {
+		file.Close()
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+	if err := file.Close(); err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+
+	fmt.Printf("✓ Экспортировано лент: %d в %s\n", len(subscriptions), path)
+	fmt.Println()
+}
+
+// printArticles выводит список статей
+func printArticles(articles []*entity.Article) {
 	for _, article := range articles {
 		readStatus := " "
 		if article.IsRead {
@@ -197,6 +467,15 @@ func (c *CLI) handleListArticles(feedID int) {
 		if article.PublicationDate != nil {
 			fmt.Printf("      Дата: %s\n", article.PublicationDate.Format("2006-01-02 15:04:05"))
 		}
+		if len(article.Authors) > 0 {
+			fmt.Printf("      Авторы: %s\n", strings.Join(article.Authors, ", "))
+		}
+		if article.Link != "" {
+			fmt.Printf("      Ссылка: %s\n", article.Link)
+		}
+		for _, enclosure := range article.Enclosures {
+			fmt.Printf("      Вложение: %s\n", enclosure.URL)
+		}
 		if article.Content != "" && len(article.Content) > 100 {
 			fmt.Printf("      %s...\n", article.Content[:100])
 		} else if article.Content != "" {
@@ -208,12 +487,16 @@ func (c *CLI) handleListArticles(feedID int) {
 
 func (c *CLI) printHelp() {
 	fmt.Println("Доступные команды:")
-	fmt.Println("  add <url>          - Добавить RSS-ленту")
-	fmt.Println("  list-feeds         - Показать все RSS-ленты")
-	fmt.Println("  fetch <feed-id>    - Обновить статьи из ленты")
-	fmt.Println("  articles [feed-id] - Показать статьи (опционально для конкретной ленты)")
-	fmt.Println("  help               - Показать эту справку")
-	fmt.Println("  exit               - Выход")
+	fmt.Println("  add <url>                     - Добавить RSS-ленту")
+	fmt.Println("  list-feeds                    - Показать все RSS-ленты и их статус")
+	fmt.Println("  fetch <feed-id>               - Обновить статьи из ленты")
+	fmt.Println("  articles [feed-id] [query]    - Показать статьи (опционально для ленты и по поисковому запросу)")
+	fmt.Println("  categories                    - Показать категории и число непрочитанных статей")
+	fmt.Println("  categorize <feed-id> <name>   - Добавить ленту в категорию")
+	fmt.Println("  uncategorize <feed-id> <name> - Убрать ленту из категории")
+	fmt.Println("  import-opml <file>            - Импортировать ленты из OPML-файла")
+	fmt.Println("  export-opml <file>            - Экспортировать ленты в OPML-файл")
+	fmt.Println("  help                          - Показать эту справку")
+	fmt.Println("  exit                          - Выход")
 	fmt.Println()
 }
-
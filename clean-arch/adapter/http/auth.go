@@ -0,0 +1,275 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	api "rss-aggregator/gen"
+	"rss-aggregator/internal/database"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// minPasswordLength is the shortest password accepted at registration
+const minPasswordLength = 8
+
+// Keys of the values Authenticate stores in fiber.Ctx.Locals
+const (
+	userLocal      = "user"
+	tokenHashLocal = "token_hash"
+)
+
+// Authenticate is a middleware that resolves the bearer token of a request to
+// its user. Requests without a valid token are rejected with 401, except the
+// ones that register a user or log in. Output feeds also take a feed token
+// from the token query parameter, since feed readers cannot set headers; the
+// API token is accepted in the header only, so it never ends up in URLs.
+func (s *Service) Authenticate(c *fiber.Ctx) error {
+	if c.Method() == fiber.MethodPost && (c.Path() == "/auth/register" || c.Path() == "/auth/login") {
+		return c.Next()
+	}
+
+	token, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !found && c.Method() == fiber.MethodGet && strings.HasPrefix(c.Path(), "/output/") && c.Query("token") != "" {
+		return s.authenticateFeedToken(c, c.Query("token"))
+	}
+	if !found || token == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Missing API token",
+		})
+	}
+
+	tokenHash := hashToken(token)
+	user, err := s.db.GetUserByTokenHash(c.UserContext(), tokenHash)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to check API token",
+		})
+	}
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid API token",
+		})
+	}
+
+	c.Locals(userLocal, user)
+	c.Locals(tokenHashLocal, tokenHash)
+	return c.Next()
+}
+
+// authenticateFeedToken resolves the feed token of an output feed request
+// to its user
+func (s *Service) authenticateFeedToken(c *fiber.Ctx, token string) error {
+	user, err := s.db.GetUserByFeedTokenHash(c.UserContext(), hashToken(token))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to check feed token",
+		})
+	}
+	if user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid feed token",
+		})
+	}
+
+	c.Locals(userLocal, user)
+	return c.Next()
+}
+
+// currentUser returns the user resolved by Authenticate
+func currentUser(c *fiber.Ctx) *database.User {
+	return c.Locals(userLocal).(*database.User)
+}
+
+// PostAuthRegister handles POST /auth/register request
+func (s *Service) PostAuthRegister(c *fiber.Ctx) error {
+	var req api.RegisterRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	name := strings.TrimSpace(req.Name)
+	email := normalizeEmail(req.Email)
+	if name == "" || email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Name and email are required",
+		})
+	}
+	if !strings.Contains(email, "@") {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid email",
+		})
+	}
+	if len(req.Password) < minPasswordLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Password must be at least 8 characters long",
+		})
+	}
+
+	existing, err := s.db.GetUserByEmail(c.UserContext(), email)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to check user existence",
+		})
+	}
+	if existing != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "User with this email already exists",
+		})
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid password",
+		})
+	}
+
+	user, err := s.db.CreateUser(c.UserContext(), name, email, string(passwordHash))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create user",
+		})
+	}
+
+	token, err := s.issueToken(c.UserContext(), user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to issue API token",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(api.AuthResponse{
+		User:  toAPIUser(user),
+		Token: token,
+	})
+}
+
+// PostAuthLogin handles POST /auth/login request
+func (s *Service) PostAuthLogin(c *fiber.Ctx) error {
+	var req api.LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	user, err := s.db.GetUserByEmail(c.UserContext(), normalizeEmail(req.Email))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve user",
+		})
+	}
+	// Accounts created before passwords existed cannot log in
+	if user == nil || user.PasswordHash == nil ||
+		bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(req.Password)) != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid email or password",
+		})
+	}
+
+	token, err := s.issueToken(c.UserContext(), user.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to issue API token",
+		})
+	}
+
+	return c.JSON(api.AuthResponse{
+		User:  toAPIUser(user),
+		Token: token,
+	})
+}
+
+// PostAuthLogout handles POST /auth/logout request
+func (s *Service) PostAuthLogout(c *fiber.Ctx) error {
+	if err := s.db.DeleteAPIToken(c.UserContext(), c.Locals(tokenHashLocal).(string)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to revoke API token",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetAuthMe handles GET /auth/me request
+func (s *Service) GetAuthMe(c *fiber.Ctx) error {
+	return c.JSON(toAPIUser(currentUser(c)))
+}
+
+// PostAuthFeedToken handles POST /auth/feed-token request. Issuing a feed
+// token revokes the previous one.
+func (s *Service) PostAuthFeedToken(c *fiber.Ctx) error {
+	token, err := newToken()
+	if err == nil {
+		err = s.db.SetFeedToken(c.UserContext(), currentUser(c).ID, hashToken(token))
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to issue feed token",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(api.FeedTokenResponse{Token: token})
+}
+
+// DeleteAuthFeedToken handles DELETE /auth/feed-token request
+func (s *Service) DeleteAuthFeedToken(c *fiber.Ctx) error {
+	if err := s.db.DeleteFeedToken(c.UserContext(), currentUser(c).ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to revoke feed token",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// issueToken creates a new API token for a user. Only its hash is stored,
+// so the token can be shown to the client just once.
+func (s *Service) issueToken(ctx context.Context, userID int) (string, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.db.CreateAPIToken(ctx, userID, hashToken(token)); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// newToken generates a random API or feed token
+func newToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// hashToken returns the stored form of an API or feed token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeEmail makes email lookups case-insensitive
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// toAPIUser converts a database user to the API model
+func toAPIUser(user *database.User) api.User {
+	return api.User{
+		Id:    user.ID,
+		Name:  user.Name,
+		Email: user.Email,
+	}
+}
@@ -0,0 +1,205 @@
+package http
+
+import (
+	"errors"
+	"strings"
+
+	"rss-aggregator/clean-arch/entity"
+	"rss-aggregator/clean-arch/usecase"
+	api "rss-aggregator/gen"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetCategories handles GET /categories request
+func (s *Service) GetCategories(c *fiber.Ctx) error {
+	summaries, err := s.listCategoriesUseCase(currentUser(c).ID).Execute(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list categories",
+		})
+	}
+
+	categories := make([]api.Category, 0, len(summaries))
+	for _, summary := range summaries {
+		categories = append(categories, toAPICategory(summary))
+	}
+
+	return c.JSON(api.CategoryListResponse{Categories: &categories})
+}
+
+// PostCategories handles POST /categories request
+func (s *Service) PostCategories(c *fiber.Ctx) error {
+	name, err := parseCategoryRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	_, _, categoryRepo := s.repositories(currentUser(c).ID)
+	category, err := usecase.NewManageCategoriesUseCase(categoryRepo).Create(c.UserContext(), name)
+	if errors.Is(err, usecase.ErrCategoryExists) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Category with this name already exists",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create category",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(toAPICategory(entity.CategorySummary{
+		Category: *category,
+		FeedIDs:  []int{},
+	}))
+}
+
+// GetCategoriesId handles GET /categories/{id} request
+func (s *Service) GetCategoriesId(c *fiber.Ctx, id int) error {
+	return s.sendCategory(c, id)
+}
+
+// PatchCategoriesId handles PATCH /categories/{id} request
+func (s *Service) PatchCategoriesId(c *fiber.Ctx, id int) error {
+	name, err := parseCategoryRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	_, _, categoryRepo := s.repositories(currentUser(c).ID)
+	err = usecase.NewManageCategoriesUseCase(categoryRepo).Rename(c.UserContext(), id, name)
+	switch {
+	case errors.Is(err, usecase.ErrCategoryNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Category not found",
+		})
+	case errors.Is(err, usecase.ErrCategoryExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Category with this name already exists",
+		})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update category",
+		})
+	}
+
+	return s.sendCategory(c, id)
+}
+
+// DeleteCategoriesId handles DELETE /categories/{id} request
+func (s *Service) DeleteCategoriesId(c *fiber.Ctx, id int) error {
+	_, _, categoryRepo := s.repositories(currentUser(c).ID)
+	err := usecase.NewManageCategoriesUseCase(categoryRepo).Delete(c.UserContext(), id)
+	if errors.Is(err, usecase.ErrCategoryNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Category not found",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete category",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// PutCategoriesIdFeedsFeedId handles PUT /categories/{id}/feeds/{feed_id} request
+func (s *Service) PutCategoriesIdFeedsFeedId(c *fiber.Ctx, id int, feedId int) error {
+	err := s.categorizeFeedUseCase(currentUser(c).ID).AssignByID(c.UserContext(), feedId, id)
+	switch {
+	case errors.Is(err, usecase.ErrCategoryNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Category not found",
+		})
+	case errors.Is(err, usecase.ErrFeedNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Feed not found",
+		})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to add feed to category",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// DeleteCategoriesIdFeedsFeedId handles DELETE /categories/{id}/feeds/{feed_id} request
+func (s *Service) DeleteCategoriesIdFeedsFeedId(c *fiber.Ctx, id int, feedId int) error {
+	err := s.categorizeFeedUseCase(currentUser(c).ID).UnassignByID(c.UserContext(), feedId, id)
+	switch {
+	case errors.Is(err, usecase.ErrCategoryNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Category not found",
+		})
+	case errors.Is(err, usecase.ErrFeedNotInCategory):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Feed is not in this category",
+		})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to remove feed from category",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// sendCategory responds with the summary of a category
+func (s *Service) sendCategory(c *fiber.Ctx, id int) error {
+	summary, err := s.listCategoriesUseCase(currentUser(c).ID).Get(c.UserContext(), id)
+	if errors.Is(err, usecase.ErrCategoryNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Category not found",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve category",
+		})
+	}
+
+	return c.JSON(toAPICategory(*summary))
+}
+
+// listCategoriesUseCase builds the use case that summarizes the categories of a user
+func (s *Service) listCategoriesUseCase(userID int) *usecase.ListCategoriesUseCase {
+	_, articles, categories := s.repositories(userID)
+	return usecase.NewListCategoriesUseCase(categories, articles)
+}
+
+// categorizeFeedUseCase builds the use case that puts feeds of a user into categories
+func (s *Service) categorizeFeedUseCase(userID int) *usecase.CategorizeFeedUseCase {
+	feeds, _, categories := s.repositories(userID)
+	return usecase.NewCategorizeFeedUseCase(feeds, categories)
+}
+
+// parseCategoryRequest reads and validates the category name from the request body
+func parseCategoryRequest(c *fiber.Ctx) (string, error) {
+	var req api.CategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return "", errors.New("Invalid request body")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return "", errors.New("Name is required")
+	}
+
+	return name, nil
+}
+
+// toAPICategory converts a category summary to the API model
+func toAPICategory(summary entity.CategorySummary) api.Category {
+	return api.Category{
+		Id:          &summary.ID,
+		Name:        &summary.Name,
+		FeedIds:     &summary.FeedIDs,
+		UnreadCount: &summary.UnreadCount,
+	}
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// articleCursor is the JSON payload behind the opaque next_cursor value
+type articleCursor struct {
+	PublicationDate *time.Time `json:"d,omitempty"`
+	ID              int        `json:"id"`
+}
+
+// encodeArticleCursor turns a page position into an opaque string
+func encodeArticleCursor(cursor *entity.ArticleCursor) string {
+	payload, _ := json.Marshal(articleCursor{
+		PublicationDate: cursor.PublicationDate,
+		ID:              cursor.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeArticleCursor parses a value produced by encodeArticleCursor
+func decodeArticleCursor(value string) (*entity.ArticleCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor articleCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.ID <= 0 {
+		return nil, errors.New("cursor has no article ID")
+	}
+
+	return &entity.ArticleCursor{
+		PublicationDate: cursor.PublicationDate,
+		ID:              cursor.ID,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net"
+	"syscall"
+	"time"
+)
+
+// disconnectPollInterval is how often a running request checks whether its
+// client has closed the connection
+const disconnectPollInterval = 200 * time.Millisecond
+
+// watchDisconnect calls cancel once the peer closes conn and returns a
+// function that stops watching. fasthttp does not read from a connection
+// while its handler runs, so the watcher peeks at the socket instead; on
+// connections it cannot peek at, e.g. TLS, it does nothing.
+func watchDisconnect(conn net.Conn, cancel func()) (stop func()) {
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return func() {}
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(disconnectPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if peerClosed(raw) {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
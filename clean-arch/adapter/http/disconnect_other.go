@@ -0,0 +1,11 @@
+//go:build !unix
+
+package http
+
+import "syscall"
+
+// peerClosed cannot peek at sockets on this platform, so a disconnect is
+// only noticed when the response is written
+func peerClosed(raw syscall.RawConn) bool {
+	return false
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package http
+
+import "syscall"
+
+// peerClosed reports whether the peer has closed the connection. It peeks
+// without blocking, so data the client already sent stays unread.
+func peerClosed(raw syscall.RawConn) bool {
+	closed := false
+	err := raw.Read(func(fd uintptr) bool {
+		var buf [1]byte
+		n, _, err := syscall.Recvfrom(int(fd), buf[:], syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+		// A read of nothing without an error is the end of the stream;
+		// EAGAIN means the connection is open but idle
+		closed = n == 0 && err == nil || err == syscall.ECONNRESET
+		return true
+	})
+	return err != nil || closed
+}
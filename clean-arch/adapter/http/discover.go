@@ -0,0 +1,54 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"rss-aggregator/clean-arch/usecase"
+	api "rss-aggregator/gen"
+	"rss-aggregator/internal/rss"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetDiscover handles GET /discover request
+func (s *Service) GetDiscover(c *fiber.Ctx, params api.GetDiscoverParams) error {
+	var pageURL string
+	if params.Url != nil {
+		pageURL = strings.TrimSpace(*params.Url)
+	}
+	if pageURL == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "url is required",
+		})
+	}
+
+	found, err := usecase.NewDiscoverFeedsUseCase(s.feedParser).Execute(c.UserContext(), pageURL)
+	var fetchErr *usecase.FetchError
+	switch {
+	case errors.As(err, &fetchErr) && rss.IsTimeout(fetchErr.Err):
+		return c.Status(fiber.StatusGatewayTimeout).JSON(fiber.Map{
+			"error": "Timed out fetching page",
+		})
+	case errors.As(err, &fetchErr):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("Failed to fetch page: %v", fetchErr.Err),
+		})
+	case err != nil:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to discover feeds",
+		})
+	}
+
+	candidates := make([]api.FeedCandidate, 0, len(found))
+	for _, candidate := range found {
+		converted := api.FeedCandidate{Url: candidate.URL, Type: candidate.Type}
+		if candidate.Title != "" {
+			converted.Title = &candidate.Title
+		}
+		candidates = append(candidates, converted)
+	}
+
+	return c.JSON(api.DiscoverResponse{Candidates: &candidates})
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"rss-aggregator/clean-arch/adapter/storerepo"
+	api "rss-aggregator/gen"
+	"rss-aggregator/internal/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetFeedsIdHealth handles GET /feeds/{id}/health request
+func (s *Service) GetFeedsIdHealth(c *fiber.Ctx, id int) error {
+	return s.sendFeedHealth(c, id)
+}
+
+// PostFeedsIdEnable handles POST /feeds/{id}/enable request. Enabling a feed
+// that is not disabled changes nothing.
+func (s *Service) PostFeedsIdEnable(c *fiber.Ctx, id int) error {
+	feed, err := s.db.GetUserFeed(c.UserContext(), currentUser(c).ID, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve feed",
+		})
+	}
+	if feed == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Feed not found",
+		})
+	}
+
+	if _, err := s.db.EnableFeed(c.UserContext(), feed.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to enable feed",
+		})
+	}
+
+	return s.sendFeedHealth(c, feed.ID)
+}
+
+// sendFeedHealth answers with the health of a feed the user subscribes to
+func (s *Service) sendFeedHealth(c *fiber.Ctx, id int) error {
+	feed, err := s.db.GetUserFeed(c.UserContext(), currentUser(c).ID, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve feed",
+		})
+	}
+	if feed == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Feed not found",
+		})
+	}
+
+	return c.JSON(toAPIFeedHealth(&feed.Health))
+}
+
+// toAPIFeedHealth converts the health of a feed to the API model. The
+// status is summarized by the entity.
+func toAPIFeedHealth(health *database.FeedHealth) api.FeedHealth {
+	status := api.FeedStatus(storerepo.ToEntityFeedHealth(*health).Status())
+	return api.FeedHealth{
+		FeedId:              &health.FeedID,
+		Status:              &status,
+		ConsecutiveFailures: &health.ConsecutiveFailures,
+		LastHttpStatus:      health.LastHTTPStatus,
+		LastError:           health.LastError,
+		LastParseError:      health.LastParseError,
+		LastFetchedAt:       health.LastFetchedAt,
+		LastSuccessAt:       health.LastSuccessAt,
+		NextFetchAt:         health.NextFetchAt,
+		DisabledAt:          health.DisabledAt,
+	}
+}
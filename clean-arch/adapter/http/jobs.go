@@ -0,0 +1,87 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "rss-aggregator/gen"
+	"rss-aggregator/internal/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// queueFeedJob queues a job adding the feed at url for the current user and
+// answers 202 with the job
+func (s *Service) queueFeedJob(c *fiber.Ctx, url string) error {
+	job, err := s.db.CreateFeedJob(c.UserContext(), currentUser(c).ID, url)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to queue feed",
+		})
+	}
+
+	// Wake an idle worker; a pending signal already does
+	select {
+	case s.jobQueued <- struct{}{}:
+	default:
+	}
+
+	c.Location(fmt.Sprintf("/jobs/%d", job.ID))
+	return c.Status(fiber.StatusAccepted).JSON(toAPIJob(job))
+}
+
+// JobQueued returns a channel signalled when a feed job is queued, so the job
+// workers need not wait for their next poll
+func (s *Service) JobQueued() <-chan struct{} {
+	return s.jobQueued
+}
+
+// RunFeedJob adds the feed of a job for the user who queued it and returns
+// the feed ID. The error carries the message the synchronous request would
+// have answered with.
+func (s *Service) RunFeedJob(ctx context.Context, job database.FeedJob) (int, error) {
+	feed, result, err := s.addFeedUseCase(job.UserID).Execute(ctx, job.URL)
+	if err != nil {
+		_, message := addFeedError(err)
+		return 0, errors.New(message)
+	}
+	logArticleErrors(feed.ID, result)
+	if result.Created > 0 {
+		s.articlesAdded()
+	}
+
+	return feed.ID, nil
+}
+
+// GetJobsId handles GET /jobs/{id} request
+func (s *Service) GetJobsId(c *fiber.Ctx, id int) error {
+	job, err := s.db.GetFeedJob(c.UserContext(), currentUser(c).ID, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve job",
+		})
+	}
+	if job == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Job not found",
+		})
+	}
+
+	return c.JSON(toAPIJob(job))
+}
+
+// toAPIJob converts a database feed job to the API model
+func toAPIJob(job *database.FeedJob) api.Job {
+	state := api.JobState(job.State)
+	return api.Job{
+		Id:         &job.ID,
+		Url:        &job.URL,
+		State:      &state,
+		Error:      job.Error,
+		FeedId:     job.FeedID,
+		CreatedAt:  &job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"bytes"
+
+	"rss-aggregator/clean-arch/entity"
+	"rss-aggregator/clean-arch/usecase"
+	api "rss-aggregator/gen"
+	"rss-aggregator/internal/opml"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PostOpmlImport handles POST /opml/import request
+func (s *Service) PostOpmlImport(c *fiber.Ctx) error {
+	subscriptions, err := opml.Parse(bytes.NewReader(c.Body()))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	entitySubscriptions := make([]entity.Subscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		entitySubscriptions = append(entitySubscriptions, entity.Subscription{
+			URL:        subscription.URL,
+			Title:      subscription.Title,
+			Categories: subscription.Categories,
+		})
+	}
+
+	feeds, articles, categories := s.repositories(currentUser(c).ID)
+	imported := usecase.NewImportFeedsUseCase(feeds, articles, categories, s.feedParser).
+		Execute(c.UserContext(), entitySubscriptions)
+	s.articlesAdded()
+
+	results := make([]api.OPMLImportResult, 0, len(imported))
+	for i, result := range imported {
+		results = append(results, toAPIImportResult(result, subscriptions[i].Categories))
+	}
+
+	var added, existing, failed int
+	for _, result := range results {
+		switch result.Status {
+		case api.OPMLImportResultStatusAdded:
+			added++
+		case api.OPMLImportResultStatusExists:
+			existing++
+		case api.OPMLImportResultStatusFailed:
+			failed++
+		}
+	}
+
+	return c.JSON(api.OPMLImportResponse{
+		Results:  &results,
+		Added:    &added,
+		Existing: &existing,
+		Failed:   &failed,
+	})
+}
+
+// toAPIImportResult converts the import result of a subscription placed
+// into the given categories
+func toAPIImportResult(result entity.ImportResult, categories []string) api.OPMLImportResult {
+	converted := api.OPMLImportResult{Url: result.URL}
+	switch result.Status {
+	case entity.ImportStatusAdded:
+		converted.Status = api.OPMLImportResultStatusAdded
+	case entity.ImportStatusExists:
+		converted.Status = api.OPMLImportResultStatusExists
+	default:
+		message := result.Err.Error()
+		converted.Status = api.OPMLImportResultStatusFailed
+		converted.Error = &message
+		return converted
+	}
+
+	if categories == nil {
+		categories = []string{}
+	}
+	converted.FeedId = &result.Feed.ID
+	converted.Categories = &categories
+	return converted
+}
+
+// GetOpmlExport handles GET /opml/export request
+func (s *Service) GetOpmlExport(c *fiber.Ctx) error {
+	feeds, _, categories := s.repositories(currentUser(c).ID)
+	exported, err := usecase.NewExportFeedsUseCase(feeds, categories).Execute(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list feeds",
+		})
+	}
+
+	subscriptions := make([]opml.Subscription, 0, len(exported))
+	for _, subscription := range exported {
+		subscriptions = append(subscriptions, opml.Subscription{
+			URL:        subscription.URL,
+			Title:      subscription.Title,
+			Categories: subscription.Categories,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := opml.Write(&buf, "RSS Aggregator subscriptions", subscriptions); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to write OPML",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/x-opml; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="subscriptions.opml"`)
+	return c.Send(buf.Bytes())
+}
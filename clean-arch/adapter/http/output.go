@@ -0,0 +1,193 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	api "rss-aggregator/gen"
+	"rss-aggregator/internal/database"
+	"rss-aggregator/internal/rss"
+	"rss-aggregator/internal/syndication"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// defaultOutputLimit is the number of articles in an output feed when a
+	// client does not specify it
+	defaultOutputLimit = 50
+	// allOutput names the output feed of all the user's feeds
+	allOutput = "all"
+)
+
+// GetOutputName handles GET /output/{name} request. The name is "all" or a
+// category followed by the format, e.g. all.rss or 3.atom.
+func (s *Service) GetOutputName(c *fiber.Ctx, name string, params api.GetOutputNameParams) error {
+	limit := defaultOutputLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxPageLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("limit must be between 1 and %d", maxPageLimit),
+		})
+	}
+
+	// Category names may contain dots, the format never does
+	dot := strings.LastIndex(name, ".")
+	selector, format := name, ""
+	if dot >= 0 {
+		selector, format = name[:dot], name[dot+1:]
+	}
+	if syndication.ContentType(format) == "" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Unknown feed format",
+		})
+	}
+
+	user := currentUser(c)
+	feed := syndication.Feed{
+		Title:       "RSS Aggregator: all feeds",
+		Description: fmt.Sprintf("Articles of the feeds of %s", user.Name),
+		// The token a feed reader authenticates with stays out of the feed
+		SelfURL: c.BaseURL() + c.Path(),
+	}
+	var categoryIDs []int
+	if selector != allOutput {
+		category, err := s.outputCategory(c.UserContext(), user.ID, selector)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to retrieve category",
+			})
+		}
+		if category == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Category not found",
+			})
+		}
+		categoryIDs = []int{category.ID}
+		feed.Title = "RSS Aggregator: " + category.Name
+		feed.Description = fmt.Sprintf("Articles of the %s feeds of %s", category.Name, user.Name)
+	}
+
+	items, err := s.outputItems(c.UserContext(), user.ID, categoryIDs, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list articles",
+		})
+	}
+	feed.Items = items
+	feed.Updated = syndication.LastUpdated(items)
+
+	var buf bytes.Buffer
+	if err := syndication.Write(&buf, format, feed); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to write feed",
+		})
+	}
+
+	etag := syndication.ETag(buf.Bytes())
+	c.Set(fiber.HeaderETag, etag)
+	if syndication.ETagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, syndication.ContentType(format))
+	return c.Send(buf.Bytes())
+}
+
+// outputCategory finds a category of the user by ID or, failing that, by name
+func (s *Service) outputCategory(ctx context.Context, userID int, selector string) (*database.Category, error) {
+	if id, err := strconv.Atoi(selector); err == nil {
+		category, err := s.db.GetCategoryByID(ctx, userID, id)
+		if err != nil || category != nil {
+			return category, err
+		}
+	}
+	return s.db.GetCategoryByName(ctx, userID, selector)
+}
+
+// outputItems reads up to limit articles of the user's feeds, newest first,
+// and converts them to feed items. An article whose link or GUID was seen
+// already, e.g. one posted to two blogs of the team, is left out.
+func (s *Service) outputItems(ctx context.Context, userID int, categoryIDs []int, limit int) ([]syndication.Item, error) {
+	feeds, err := s.db.ListAllFeeds(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sources := make(map[int]syndication.Source, len(feeds))
+	for _, feed := range feeds {
+		sources[feed.ID] = syndication.Source{Title: deref(feed.Title), URL: feed.URL}
+	}
+
+	filter := database.ArticleFilter{
+		UserID:      userID,
+		CategoryIDs: categoryIDs,
+		Limit:       limit,
+	}
+	seen := make(map[string]bool)
+	items := []syndication.Item{}
+	for len(items) < limit {
+		articles, next, err := s.db.ListArticles(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, article := range articles {
+			item := toSyndicationItem(article, sources[article.FeedID])
+			if seen[item.ID] || len(items) == limit {
+				continue
+			}
+			seen[item.ID] = true
+			items = append(items, item)
+		}
+		if next == nil {
+			break
+		}
+		filter.After = next
+	}
+
+	return items, nil
+}
+
+// toSyndicationItem converts a stored article to a feed item. Its ID is the
+// link, then the GUID of the article, so that copies of the same post share it.
+// Feed items carry HTML, so plain text content is escaped.
+func toSyndicationItem(article database.Article, source syndication.Source) syndication.Item {
+	content := deref(article.Content)
+	if deref(article.ContentType) == rss.ContentTypeText {
+		content = html.EscapeString(content)
+	}
+
+	item := syndication.Item{
+		ID:         syndication.ItemID(article.ID),
+		Link:       strings.TrimSpace(deref(article.Link)),
+		Title:      article.Title,
+		Content:    content,
+		Summary:    deref(article.Summary),
+		Published:  article.PublishedAt,
+		Updated:    article.UpdatedAt,
+		Authors:    article.Authors,
+		Categories: article.Categories,
+		Source:     source,
+	}
+	if item.Published == nil {
+		item.Published = article.PublicationDate
+	}
+	if item.Link != "" {
+		item.ID = item.Link
+	} else if guid := strings.TrimSpace(deref(article.GUID)); guid != "" {
+		item.ID = guid
+	}
+	for _, enclosure := range article.Enclosures {
+		converted := syndication.Enclosure{URL: enclosure.URL, Type: deref(enclosure.Type)}
+		if enclosure.Length != nil {
+			converted.Length = *enclosure.Length
+		}
+		item.Enclosures = append(item.Enclosures, converted)
+	}
+	return item
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"rss-aggregator/clean-arch/entity"
+	"rss-aggregator/clean-arch/usecase"
+	api "rss-aggregator/gen"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetSearch handles GET /search request
+func (s *Service) GetSearch(c *fiber.Ctx, params api.GetSearchParams) error {
+	var query string
+	if params.Q != nil {
+		query = strings.TrimSpace(*params.Q)
+	}
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "q is required",
+		})
+	}
+
+	filter := entity.SearchFilter{
+		Query:  query,
+		IsRead: params.IsRead,
+		Limit:  defaultPageLimit,
+	}
+	if params.FeedId != nil {
+		filter.FeedIDs = *params.FeedId
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+	if filter.Limit < 1 || filter.Limit > maxPageLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("limit must be between 1 and %d", maxPageLimit),
+		})
+	}
+	if params.Offset != nil {
+		filter.Offset = *params.Offset
+	}
+	if filter.Offset < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "offset must not be negative",
+		})
+	}
+	if !validArticleFormat(params.Format) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "format must be html or text",
+		})
+	}
+
+	_, articleRepo, _ := s.repositories(currentUser(c).ID)
+	found, total, err := usecase.NewSearchArticlePageUseCase(articleRepo).Execute(c.UserContext(), filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to search articles",
+		})
+	}
+
+	results := make([]api.SearchResult, 0, len(found))
+	for _, result := range found {
+		article := formatArticles(toAPIEntityArticles([]*entity.Article{result.Article}), params.Format)[0]
+		results = append(results, api.SearchResult{
+			Article:        &article,
+			TitleHighlight: &result.TitleHighlight,
+			Snippet:        &result.Snippet,
+			Rank:           &result.Rank,
+		})
+	}
+
+	return c.JSON(api.SearchResponse{
+		Results: &results,
+		Total:   &total,
+		Limit:   &filter.Limit,
+		Offset:  &filter.Offset,
+	})
+}
@@ -0,0 +1,597 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"rss-aggregator/clean-arch/adapter"
+	"rss-aggregator/clean-arch/adapter/storerepo"
+	"rss-aggregator/clean-arch/entity"
+	"rss-aggregator/clean-arch/usecase"
+	api "rss-aggregator/gen"
+	"rss-aggregator/internal/database"
+	"rss-aggregator/internal/rss"
+	"rss-aggregator/internal/stream"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// defaultPageLimit is used when a client does not specify a page size
+	defaultPageLimit = 20
+	// maxPageLimit caps the page size a client may request
+	maxPageLimit = 100
+)
+
+// Service implements the ServerInterface
+type Service struct {
+	db     database.Store
+	parser *rss.Parser
+	// feedParser is the parser as seen by the use cases
+	feedParser entity.RSSParser
+	// jobQueued signals that a feed job was queued, see JobQueued
+	jobQueued chan struct{}
+	// deliveryQueued signals that webhook deliveries may have been queued,
+	// see DeliveryQueued
+	deliveryQueued chan struct{}
+	// hub tells the open article streams about new articles
+	hub *stream.Hub
+}
+
+// New creates a new service instance fetching feeds with the given parser
+func New(db database.Store, parser *rss.Parser) *Service {
+	return &Service{
+		db:             db,
+		parser:         parser,
+		feedParser:     adapter.NewRSSParserAdapter(parser),
+		jobQueued:      make(chan struct{}, 1),
+		deliveryQueued: make(chan struct{}, 1),
+		hub:            stream.NewHub(),
+	}
+}
+
+// PostFeeds handles POST /feeds request. With async=true the feed is added
+// by a job in the background.
+func (s *Service) PostFeeds(c *fiber.Ctx, params api.PostFeedsParams) error {
+	var req api.AddFeedRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if req.Url == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "URL is required",
+		})
+	}
+
+	if params.Async != nil && *params.Async {
+		return s.queueFeedJob(c, req.Url)
+	}
+
+	user := currentUser(c)
+	feed, result, err := s.addFeedUseCase(user.ID).Execute(c.UserContext(), req.Url)
+	if err != nil {
+		status, message := addFeedError(err)
+		return c.Status(status).JSON(fiber.Map{
+			"error": message,
+		})
+	}
+	logArticleErrors(feed.ID, result)
+	if result.Created > 0 {
+		s.articlesAdded()
+	}
+
+	// Get all articles for the feed
+	_, articles, _ := s.repositories(user.ID)
+	allArticles, err := usecase.NewListArticlesUseCase(articles).Execute(c.UserContext(), feed.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve articles",
+		})
+	}
+
+	response := toAPIEntityFeedResponse(feed, toAPIEntityArticles(allArticles))
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// addFeedError maps an error of the add feed use case to a status code and
+// the message reported to the client
+func addFeedError(err error) (int, string) {
+	var fetchErr *usecase.FetchError
+	switch {
+	case errors.Is(err, usecase.ErrFeedExists):
+		return fiber.StatusConflict, "Feed with this URL already exists"
+	case errors.As(err, &fetchErr) && rss.IsTimeout(fetchErr.Err):
+		return fiber.StatusGatewayTimeout, "Timed out fetching RSS feed"
+	case errors.As(err, &fetchErr):
+		return fiber.StatusBadRequest, fmt.Sprintf("Failed to parse RSS feed: %v", fetchErr.Err)
+	default:
+		return fiber.StatusInternalServerError, "Failed to create feed"
+	}
+}
+
+// logArticleErrors logs the articles of a feed that could not be stored
+func logArticleErrors(feedID int, result entity.UpsertResult) {
+	for _, articleErr := range result.Errors {
+		log.Printf("service: feed %d: failed to store %v", feedID, articleErr)
+	}
+}
+
+// repositories returns the clean-arch repositories scoped to a user. Besides
+// the common interfaces they implement the ones only the server needs, such
+// as entity.ReadingRepository.
+func (s *Service) repositories(userID int) (*storerepo.StoreFeedRepository, *storerepo.StoreArticleRepository, *storerepo.StoreCategoryRepository) {
+	return storerepo.NewStoreFeedRepository(s.db, userID),
+		storerepo.NewStoreArticleRepository(s.db, userID),
+		storerepo.NewStoreCategoryRepository(s.db, userID)
+}
+
+// addFeedUseCase builds the use case that subscribes a user to a new feed
+func (s *Service) addFeedUseCase(userID int) *usecase.AddFeedUseCase {
+	feeds, articles, _ := s.repositories(userID)
+	return usecase.NewAddFeedUseCase(feeds, articles, s.feedParser)
+}
+
+// fetchArticlesUseCase builds the use case that fetches the new articles of
+// a feed of the user
+func (s *Service) fetchArticlesUseCase(userID int) *usecase.FetchArticlesUseCase {
+	feeds, articles, _ := s.repositories(userID)
+	return usecase.NewFetchArticlesUseCase(feeds, articles, s.feedParser)
+}
+
+// markArticlesUseCase builds the use case that changes the reading state of
+// the articles of a user
+func (s *Service) markArticlesUseCase(userID int) *usecase.MarkArticlesUseCase {
+	feeds, articles, categories := s.repositories(userID)
+	return usecase.NewMarkArticlesUseCase(feeds, categories, articles)
+}
+
+// RefreshFeed fetches a stored feed again and saves its new articles.
+// The request is conditional, so an unchanged feed costs a 304 and stores nothing.
+func (s *Service) RefreshFeed(ctx context.Context, feedID int) (*entity.ParsedFeed, error) {
+	parsedFeed, result, err := s.fetchArticlesUseCase(storerepo.AllUsers).Execute(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+	logArticleErrors(feedID, result)
+	if result.Created > 0 {
+		s.articlesAdded()
+	}
+
+	return parsedFeed, nil
+}
+
+// GetFeeds handles GET /feeds request
+func (s *Service) GetFeeds(c *fiber.Ctx, params api.GetFeedsParams) error {
+	limit := defaultPageLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxPageLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("limit must be between 1 and %d", maxPageLimit),
+		})
+	}
+
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if offset < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "offset must not be negative",
+		})
+	}
+
+	feedRepo, _, _ := s.repositories(currentUser(c).ID)
+	feeds, total, err := usecase.NewListFeedPageUseCase(feedRepo).Execute(c.UserContext(), limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list feeds",
+		})
+	}
+
+	apiFeeds := make([]api.Feed, 0, len(feeds))
+	for _, feed := range feeds {
+		apiFeeds = append(apiFeeds, api.Feed{
+			Id:            &feed.ID,
+			Url:           &feed.URL,
+			Title:         &feed.Title,
+			Description:   &feed.Description,
+			Format:        (*api.FeedFormat)(nonEmpty(feed.Format)),
+			FormatVersion: nonEmpty(feed.FormatVersion),
+		})
+	}
+
+	return c.JSON(api.FeedListResponse{
+		Feeds:  &apiFeeds,
+		Total:  &total,
+		Limit:  &limit,
+		Offset: &offset,
+	})
+}
+
+// GetFeedsId handles GET /feeds/{id} request
+func (s *Service) GetFeedsId(c *fiber.Ctx, id int, params api.GetFeedsIdParams) error {
+	limit := defaultPageLimit
+	if params.ArticlesLimit != nil {
+		limit = *params.ArticlesLimit
+	}
+	if limit < 1 || limit > maxPageLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("articles_limit must be between 1 and %d", maxPageLimit),
+		})
+	}
+	if !validArticleFormat(params.Format) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "format must be html or text",
+		})
+	}
+
+	feedRepo, articleRepo, _ := s.repositories(currentUser(c).ID)
+	feed, recentArticles, err := usecase.NewGetFeedUseCase(feedRepo, articleRepo).Execute(c.UserContext(), id, limit)
+	if errors.Is(err, usecase.ErrFeedNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Feed not found",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve feed",
+		})
+	}
+
+	articles := formatArticles(toAPIEntityArticles(recentArticles), params.Format)
+	return c.JSON(toAPIEntityFeedResponse(feed, articles))
+}
+
+// DeleteFeedsId handles DELETE /feeds/{id} request. It unsubscribes the user;
+// the feed is deleted once nobody subscribes to it.
+func (s *Service) DeleteFeedsId(c *fiber.Ctx, id int) error {
+	feedRepo, _, _ := s.repositories(currentUser(c).ID)
+	err := usecase.NewUnsubscribeFeedUseCase(feedRepo).Execute(c.UserContext(), id)
+	if errors.Is(err, usecase.ErrFeedNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Feed not found",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete feed",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetArticles handles GET /articles request
+func (s *Service) GetArticles(c *fiber.Ctx, params api.GetArticlesParams) error {
+	filter := entity.ArticleFilter{
+		IsRead:    params.IsRead,
+		IsStarred: params.IsStarred,
+		From:      params.From,
+		To:        params.To,
+		Limit:     defaultPageLimit,
+	}
+	if params.FeedId != nil {
+		filter.FeedIDs = *params.FeedId
+	}
+	if params.CategoryId != nil {
+		filter.CategoryIDs = *params.CategoryId
+	}
+	if params.Q != nil {
+		filter.TitleQuery = *params.Q
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+	if filter.Limit < 1 || filter.Limit > maxPageLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("limit must be between 1 and %d", maxPageLimit),
+		})
+	}
+	if params.Cursor != nil && *params.Cursor != "" {
+		cursor, err := decodeArticleCursor(*params.Cursor)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid cursor",
+			})
+		}
+		filter.After = cursor
+	}
+	if !validArticleFormat(params.Format) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "format must be html or text",
+		})
+	}
+
+	_, articleRepo, _ := s.repositories(currentUser(c).ID)
+	found, next, err := usecase.NewListArticlePageUseCase(articleRepo).Execute(c.UserContext(), filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list articles",
+		})
+	}
+
+	articles := formatArticles(toAPIEntityArticles(found), params.Format)
+	response := api.ArticleListResponse{
+		Articles: &articles,
+	}
+	if next != nil {
+		cursor := encodeArticleCursor(next)
+		response.NextCursor = &cursor
+	}
+
+	return c.JSON(response)
+}
+
+// PatchArticlesId handles PATCH /articles/{id} request
+func (s *Service) PatchArticlesId(c *fiber.Ctx, id int) error {
+	var req api.UpdateArticleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if req.IsRead == nil && req.IsStarred == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "is_read or is_starred is required",
+		})
+	}
+
+	article, err := s.markArticlesUseCase(currentUser(c).ID).SetState(c.UserContext(), id, req.IsRead, req.IsStarred)
+	if errors.Is(err, usecase.ErrArticleNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Article not found",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update article",
+		})
+	}
+
+	return c.JSON(toAPIEntityArticles([]*entity.Article{article})[0])
+}
+
+// PostArticlesMarkRead handles POST /articles/mark-read request
+func (s *Service) PostArticlesMarkRead(c *fiber.Ctx) error {
+	var req api.MarkReadBeforeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if req.Before.IsZero() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "before is required",
+		})
+	}
+
+	updated, err := s.markArticlesUseCase(currentUser(c).ID).MarkReadBefore(c.UserContext(), req.Before)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to mark articles as read",
+		})
+	}
+
+	return c.JSON(toMarkReadResponse(updated))
+}
+
+// PostFeedsIdMarkRead handles POST /feeds/{id}/mark-read request
+func (s *Service) PostFeedsIdMarkRead(c *fiber.Ctx, id int) error {
+	updated, err := s.markArticlesUseCase(currentUser(c).ID).MarkFeedRead(c.UserContext(), id)
+	if errors.Is(err, usecase.ErrFeedNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Feed not found",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to mark articles as read",
+		})
+	}
+
+	return c.JSON(toMarkReadResponse(updated))
+}
+
+// PostCategoriesIdMarkRead handles POST /categories/{id}/mark-read request
+func (s *Service) PostCategoriesIdMarkRead(c *fiber.Ctx, id int) error {
+	updated, err := s.markArticlesUseCase(currentUser(c).ID).MarkCategoryRead(c.UserContext(), id)
+	if errors.Is(err, usecase.ErrCategoryNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Category not found",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to mark articles as read",
+		})
+	}
+
+	return c.JSON(toMarkReadResponse(updated))
+}
+
+// toMarkReadResponse builds a bulk mark-read response
+func toMarkReadResponse(updated int) api.MarkReadResponse {
+	return api.MarkReadResponse{Updated: &updated}
+}
+
+// toAPIArticles converts database articles to the API model
+func toAPIArticles(articles []database.Article) []api.Article {
+	apiArticles := make([]api.Article, 0, len(articles))
+	for _, article := range articles {
+		authors := nonNil(article.Authors)
+		categories := nonNil(article.Categories)
+		enclosures := make([]api.Enclosure, 0, len(article.Enclosures))
+		for _, enclosure := range article.Enclosures {
+			enclosures = append(enclosures, api.Enclosure{
+				Url:    enclosure.URL,
+				Type:   enclosure.Type,
+				Length: enclosure.Length,
+			})
+		}
+
+		apiArticles = append(apiArticles, api.Article{
+			Id:              &article.ID,
+			FeedId:          &article.FeedID,
+			Title:           &article.Title,
+			Content:         article.Content,
+			ContentType:     (*api.ContentType)(article.ContentType),
+			Summary:         article.Summary,
+			Link:            article.Link,
+			PublicationDate: article.PublicationDate,
+			PublishedAt:     article.PublishedAt,
+			UpdatedAt:       article.UpdatedAt,
+			Authors:         &authors,
+			Categories:      &categories,
+			Enclosures:      &enclosures,
+			ImageUrl:        article.ImageURL,
+			BannerImage:     article.BannerImage,
+			Extensions:      toAPIExtensions(article.Extensions),
+			IsRead:          &article.IsRead,
+			IsStarred:       &article.IsStarred,
+		})
+	}
+	return apiArticles
+}
+
+// toAPIEntityArticles converts articles of the use cases to the API model
+func toAPIEntityArticles(articles []*entity.Article) []api.Article {
+	apiArticles := make([]api.Article, 0, len(articles))
+	for _, article := range articles {
+		authors := nonNil(article.Authors)
+		categories := nonNil(article.Categories)
+		enclosures := make([]api.Enclosure, 0, len(article.Enclosures))
+		for _, enclosure := range article.Enclosures {
+			enclosures = append(enclosures, api.Enclosure{
+				Url:    enclosure.URL,
+				Type:   nonEmpty(enclosure.Type),
+				Length: nonZero(enclosure.Length),
+			})
+		}
+
+		apiArticles = append(apiArticles, api.Article{
+			Id:              &article.ID,
+			FeedId:          &article.FeedID,
+			Title:           &article.Title,
+			Content:         nonEmpty(article.Content),
+			ContentType:     (*api.ContentType)(nonEmpty(article.ContentType)),
+			Summary:         nonEmpty(article.Summary),
+			Link:            nonEmpty(article.Link),
+			PublicationDate: article.PublicationDate,
+			PublishedAt:     article.PublishedAt,
+			UpdatedAt:       article.UpdatedAt,
+			Authors:         &authors,
+			Categories:      &categories,
+			Enclosures:      &enclosures,
+			ImageUrl:        nonEmpty(article.ImageURL),
+			BannerImage:     nonEmpty(article.BannerImage),
+			Extensions:      toAPIExtensions(nonEmpty(article.Extensions)),
+			IsRead:          &article.IsRead,
+			IsStarred:       &article.IsStarred,
+		})
+	}
+	return apiArticles
+}
+
+// nonEmpty returns nil for an empty string
+func nonEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// nonZero returns nil for a zero length
+func nonZero(value int64) *int64 {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
+
+// validArticleFormat reports whether the format parameter is absent or supported
+func validArticleFormat(format *api.ArticleFormat) bool {
+	return format == nil || *format == api.ArticleFormatHtml || *format == api.ArticleFormatText
+}
+
+// formatArticles converts the content and summary of the articles to plain
+// text when the format parameter asks for text. The summary is HTML even
+// when the content is plain text.
+func formatArticles(articles []api.Article, format *api.ArticleFormat) []api.Article {
+	if format == nil || *format != api.ArticleFormatText {
+		return articles
+	}
+
+	contentType := api.ContentTypeText
+	for i := range articles {
+		article := &articles[i]
+		if article.ContentType == nil || *article.ContentType != api.ContentTypeText {
+			article.Content = plainText(article.Content)
+		}
+		article.Summary = plainText(article.Summary)
+		article.ContentType = &contentType
+	}
+	return articles
+}
+
+// plainText strips the markup from stored HTML, keeping nil as nil
+func plainText(content *string) *string {
+	if content == nil {
+		return nil
+	}
+	text := rss.PlainText(*content)
+	return &text
+}
+
+// toAPIExtensions decodes the stored JSON Feed extensions, nil if there are none
+func toAPIExtensions(extensions *string) *map[string]interface{} {
+	if extensions == nil {
+		return nil
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(*extensions), &decoded); err != nil {
+		return nil
+	}
+	return &decoded
+}
+
+// nonNil returns an empty slice for nil so it serializes as []
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// deref returns the value of a string, or "" for nil
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// toAPIEntityFeedResponse builds a feed response for a feed of the use cases
+func toAPIEntityFeedResponse(feed *entity.Feed, articles []api.Article) api.FeedResponse {
+	return api.FeedResponse{
+		Id:            &feed.ID,
+		Url:           &feed.URL,
+		Title:         &feed.Title,
+		Description:   &feed.Description,
+		Format:        (*api.FeedFormat)(nonEmpty(feed.Format)),
+		FormatVersion: nonEmpty(feed.FormatVersion),
+		Articles:      &articles,
+	}
+}
+
+// Ensure Service implements ServerInterface
+var _ api.ServerInterface = (*Service)(nil)
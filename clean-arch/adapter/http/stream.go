@@ -0,0 +1,247 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	api "rss-aggregator/gen"
+	"rss-aggregator/internal/database"
+	"rss-aggregator/internal/stream"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// streamBatchSize bounds the articles read from the database at once
+	streamBatchSize = 100
+	// streamKeepAlive is how often an idle stream sends a comment, which keeps
+	// proxies from closing it and reveals clients that went away
+	streamKeepAlive = 15 * time.Second
+	// streamReorderWindow is how long a stream keeps re-reading the IDs
+	// below an article it sent. IDs are taken when an insert starts, so on
+	// PostgreSQL a lower ID can commit after a higher one was streamed.
+	streamReorderWindow = 30 * time.Second
+	// streamResumeWindow is how many IDs below Last-Event-ID a resumed
+	// stream re-reads for articles committing late. IDs are shared by all
+	// users, so it counts the inserts of every feed that may be in flight.
+	streamResumeWindow = 1000
+)
+
+// CloseStreams ends the open article streams and refuses new ones. The
+// server waits for open connections on shutdown, so call it first.
+func (s *Service) CloseStreams() {
+	s.hub.Close()
+}
+
+// articlesAdded tells the webhook dispatcher and the article streams that
+// new articles may have been stored
+func (s *Service) articlesAdded() {
+	s.notifyDeliveries()
+	s.hub.Publish()
+}
+
+// GetStream handles GET /stream request. The response stays open and
+// carries every new article of the user's feeds as a Server-Sent Event.
+func (s *Service) GetStream(c *fiber.Ctx, params api.GetStreamParams) error {
+	if !validArticleFormat(params.Format) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "format must be html or text",
+		})
+	}
+
+	filter := database.NewArticleFilter{
+		UserID: currentUser(c).ID,
+		Limit:  streamBatchSize,
+	}
+	if params.FeedId != nil {
+		filter.FeedIDs = *params.FeedId
+	}
+	if params.CategoryId != nil {
+		filter.CategoryIDs = *params.CategoryId
+	}
+
+	// Subscribe before the first read so that no article falls in between
+	subscription := s.hub.Subscribe()
+
+	// A reconnecting client resumes after the last article it received;
+	// a new one starts with the articles added from now on
+	var cursor *streamCursor
+	if lastEventID := c.Get("Last-Event-ID"); lastEventID != "" {
+		id, err := strconv.Atoi(lastEventID)
+		if err != nil || id < 0 {
+			subscription.Close()
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid Last-Event-ID",
+			})
+		}
+		cursor, err = s.resumeCursor(c.UserContext(), filter, id)
+		if err != nil {
+			subscription.Close()
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to open stream",
+			})
+		}
+	} else {
+		latest, err := s.db.LatestArticleID(c.UserContext())
+		if err != nil {
+			subscription.Close()
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to open stream",
+			})
+		}
+		cursor = newStreamCursor(latest)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The writer runs after the handler has returned, when the request
+	// context is cancelled already, so it works with its own
+	format := params.Format
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer subscription.Close()
+		s.streamArticles(w, subscription, filter, cursor, format)
+	})
+	return nil
+}
+
+// resumeCursor creates the cursor of a stream resuming after the article
+// lastID. Lower IDs may still commit late, so the floor starts
+// streamResumeWindow IDs below it. The client has received the articles up
+// to lastID that exist by now, so they count as sent and only the ones
+// committing later are streamed.
+func (s *Service) resumeCursor(ctx context.Context, filter database.NewArticleFilter, lastID int) (*streamCursor, error) {
+	cursor := newStreamCursor(max(lastID-streamResumeWindow, 0))
+	now := time.Now()
+	cursor.sent[lastID] = now
+
+	filter.AfterID = cursor.floor
+	for filter.AfterID < lastID {
+		articles, err := s.db.ListNewArticles(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, article := range articles {
+			if article.ID <= lastID {
+				cursor.sent[article.ID] = now
+			}
+		}
+		if len(articles) < filter.Limit {
+			break
+		}
+		filter.AfterID = articles[len(articles)-1].ID
+	}
+
+	return cursor, nil
+}
+
+// streamArticles writes the articles matching the filter after the cursor as
+// events until the client goes away or the subscription is closed
+func (s *Service) streamArticles(w *bufio.Writer, subscription *stream.Subscription, filter database.NewArticleFilter, cursor *streamCursor, format *api.ArticleFormat) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ticker := time.NewTicker(streamKeepAlive)
+	defer ticker.Stop()
+
+	if _, err := w.WriteString(": connected\n\n"); err != nil || w.Flush() != nil {
+		return
+	}
+	for {
+		filter.AfterID = cursor.floor
+		for {
+			articles, err := s.db.ListNewArticles(ctx, filter)
+			if err != nil {
+				log.Printf("stream: failed to list new articles for user %d: %v", filter.UserID, err)
+				break
+			}
+			if len(articles) == 0 {
+				break
+			}
+			for _, article := range formatArticles(toAPIArticles(cursor.unsent(articles)), format) {
+				if err := writeArticleEvent(w, article); err != nil {
+					return
+				}
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+			filter.AfterID = articles[len(articles)-1].ID
+			if len(articles) < filter.Limit {
+				break
+			}
+		}
+		cursor.advance(time.Now())
+
+		select {
+		case <-subscription.Done():
+			return
+		case <-subscription.Notify():
+		case <-ticker.C:
+			if _, err := w.WriteString(": keep-alive\n\n"); err != nil || w.Flush() != nil {
+				return
+			}
+		}
+	}
+}
+
+// streamCursor tracks the articles a stream has sent. Those sent within
+// streamReorderWindow stay above the floor, so the next reads see the IDs
+// below them again and catch the articles that committed late.
+type streamCursor struct {
+	// floor is the ID up to which every article was sent
+	floor int
+	// sent holds when each article above the floor was sent
+	sent map[int]time.Time
+}
+
+// newStreamCursor creates a cursor for a stream resuming after an article
+func newStreamCursor(afterID int) *streamCursor {
+	return &streamCursor{floor: afterID, sent: make(map[int]time.Time)}
+}
+
+// unsent returns the articles not sent yet and records them as sent
+func (c *streamCursor) unsent(articles []database.Article) []database.Article {
+	now := time.Now()
+	fresh := make([]database.Article, 0, len(articles))
+	for _, article := range articles {
+		if _, ok := c.sent[article.ID]; ok {
+			continue
+		}
+		c.sent[article.ID] = now
+		fresh = append(fresh, article)
+	}
+	return fresh
+}
+
+// advance raises the floor to the highest article sent before the reorder
+// window, since no lower ID is expected to commit that late
+func (c *streamCursor) advance(now time.Time) {
+	for id, sentAt := range c.sent {
+		if now.Sub(sentAt) >= streamReorderWindow && id > c.floor {
+			c.floor = id
+		}
+	}
+	for id := range c.sent {
+		if id <= c.floor {
+			delete(c.sent, id)
+		}
+	}
+}
+
+// writeArticleEvent writes an article as an event whose ID is the article ID
+func writeArticleEvent(w *bufio.Writer, article api.Article) error {
+	data, err := json.Marshal(article)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: article\ndata: %s\n\n", *article.Id, data)
+	return err
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultRequestTimeout bounds the work done for a request when not configured
+const DefaultRequestTimeout = time.Minute
+
+// Timeout is a middleware that bounds the time spent on a request. Handlers
+// pass c.UserContext() on to the feed fetcher and the database, which give up
+// once the deadline passes; a request that fails because of it gets 504. The
+// context is also cancelled when the client closes the connection, since no
+// one is left to receive the response.
+func Timeout(timeout time.Duration) fiber.Handler {
+	if timeout <= 0 {
+		timeout = DefaultRequestTimeout
+	}
+
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+		stop := watchDisconnect(c.Context().Conn(), cancel)
+		defer stop()
+
+		err := c.Next()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) &&
+			(err != nil || c.Response().StatusCode() >= fiber.StatusInternalServerError) {
+			return c.Status(fiber.StatusGatewayTimeout).JSON(fiber.Map{
+				"error": "Request timed out",
+			})
+		}
+		return err
+	}
+}
@@ -0,0 +1,232 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	api "rss-aggregator/gen"
+	"rss-aggregator/internal/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DeliveryQueued returns a channel signalled when new articles may have
+// queued webhook deliveries, so the dispatcher need not wait for its next poll
+func (s *Service) DeliveryQueued() <-chan struct{} {
+	return s.deliveryQueued
+}
+
+// notifyDeliveries wakes an idle webhook dispatcher; a pending signal already does
+func (s *Service) notifyDeliveries() {
+	select {
+	case s.deliveryQueued <- struct{}{}:
+	default:
+	}
+}
+
+// GetWebhooks handles GET /webhooks request
+func (s *Service) GetWebhooks(c *fiber.Ctx) error {
+	webhooks, err := s.db.ListWebhooks(c.UserContext(), currentUser(c).ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list webhooks",
+		})
+	}
+
+	apiWebhooks := make([]api.Webhook, 0, len(webhooks))
+	for i := range webhooks {
+		apiWebhooks = append(apiWebhooks, toAPIWebhook(&webhooks[i]))
+	}
+
+	return c.JSON(api.WebhookListResponse{Webhooks: &apiWebhooks})
+}
+
+// PostWebhooks handles POST /webhooks request
+func (s *Service) PostWebhooks(c *fiber.Ctx) error {
+	var req api.WebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if target, err := url.Parse(req.Url); err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "url must be an absolute http or https URL",
+		})
+	}
+	if req.Secret == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "secret is required",
+		})
+	}
+
+	user := currentUser(c)
+	if req.FeedId != nil {
+		feed, err := s.db.GetUserFeed(c.UserContext(), user.ID, *req.FeedId)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to retrieve feed",
+			})
+		}
+		if feed == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Feed %d not found", *req.FeedId),
+			})
+		}
+	}
+	if req.CategoryId != nil {
+		category, err := s.db.GetCategoryByID(c.UserContext(), user.ID, *req.CategoryId)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to retrieve category",
+			})
+		}
+		if category == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Category %d not found", *req.CategoryId),
+			})
+		}
+	}
+
+	webhook, err := s.db.CreateWebhook(c.UserContext(), user.ID, req.Url, req.Secret, req.FeedId, req.CategoryId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create webhook",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(toAPIWebhook(webhook))
+}
+
+// GetWebhooksId handles GET /webhooks/{id} request
+func (s *Service) GetWebhooksId(c *fiber.Ctx, id int) error {
+	webhook, err := s.db.GetWebhook(c.UserContext(), currentUser(c).ID, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve webhook",
+		})
+	}
+	if webhook == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Webhook not found",
+		})
+	}
+
+	return c.JSON(toAPIWebhook(webhook))
+}
+
+// DeleteWebhooksId handles DELETE /webhooks/{id} request
+func (s *Service) DeleteWebhooksId(c *fiber.Ctx, id int) error {
+	deleted, err := s.db.DeleteWebhook(c.UserContext(), currentUser(c).ID, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete webhook",
+		})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Webhook not found",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetWebhooksIdDeliveries handles GET /webhooks/{id}/deliveries request
+func (s *Service) GetWebhooksIdDeliveries(c *fiber.Ctx, id int, params api.GetWebhooksIdDeliveriesParams) error {
+	limit := defaultPageLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxPageLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("limit must be between 1 and %d", maxPageLimit),
+		})
+	}
+
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if offset < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "offset must not be negative",
+		})
+	}
+
+	webhook, err := s.db.GetWebhook(c.UserContext(), currentUser(c).ID, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve webhook",
+		})
+	}
+	if webhook == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Webhook not found",
+		})
+	}
+
+	deliveries, err := s.db.ListWebhookDeliveries(c.UserContext(), webhook.ID, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list deliveries",
+		})
+	}
+
+	total, err := s.db.CountWebhookDeliveries(c.UserContext(), webhook.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to count deliveries",
+		})
+	}
+
+	apiDeliveries := make([]api.WebhookDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		apiDeliveries = append(apiDeliveries, toAPIWebhookDelivery(&deliveries[i]))
+	}
+
+	return c.JSON(api.WebhookDeliveryListResponse{
+		Deliveries: &apiDeliveries,
+		Total:      &total,
+		Limit:      &limit,
+		Offset:     &offset,
+	})
+}
+
+// toAPIWebhook converts a database webhook to the API model, leaving out the secret
+func toAPIWebhook(webhook *database.Webhook) api.Webhook {
+	return api.Webhook{
+		Id:         &webhook.ID,
+		Url:        &webhook.URL,
+		FeedId:     webhook.FeedID,
+		CategoryId: webhook.CategoryID,
+		CreatedAt:  &webhook.CreatedAt,
+	}
+}
+
+// toAPIWebhookDelivery converts a database delivery to the API model
+func toAPIWebhookDelivery(delivery *database.WebhookDelivery) api.WebhookDelivery {
+	state := api.WebhookDeliveryState(delivery.State)
+	converted := api.WebhookDelivery{
+		Id:          &delivery.ID,
+		WebhookId:   &delivery.WebhookID,
+		Event:       &delivery.Event,
+		State:       &state,
+		Attempts:    &delivery.Attempts,
+		LastStatus:  delivery.LastStatus,
+		LastError:   delivery.LastError,
+		CreatedAt:   &delivery.CreatedAt,
+		DeliveredAt: delivery.DeliveredAt,
+	}
+	if delivery.State == database.DeliveryPending {
+		converted.NextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(delivery.Payload), &payload); err == nil {
+		converted.Payload = &payload
+	}
+	return converted
+}
@@ -1,7 +1,10 @@
 package memoryrepo
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"rss-aggregator/clean-arch/entity"
@@ -9,36 +12,84 @@ import (
 
 // InMemoryArticleRepository реализует ArticleRepository в памяти
 type InMemoryArticleRepository struct {
-	articles map[int]*entity.Article
+	articles     map[int]*entity.Article
 	feedArticles map[int][]int
-	mu       sync.RWMutex
-	nextID   int
+	feedKeys     map[int]map[string]int
+	mu           sync.RWMutex
+	nextID       int
 }
 
 // NewInMemoryArticleRepository создает новый экземпляр InMemoryArticleRepository
 func NewInMemoryArticleRepository() *InMemoryArticleRepository {
 	return &InMemoryArticleRepository{
-		articles:    make(map[int]*entity.Article),
+		articles:     make(map[int]*entity.Article),
 		feedArticles: make(map[int][]int),
-		nextID:      1,
+		feedKeys:     make(map[int]map[string]int),
+		nextID:       1,
 	}
 }
 
 // Create создает новую статью
-func (r *InMemoryArticleRepository) Create(article *entity.Article) error {
+func (r *InMemoryArticleRepository) Create(ctx context.Context, article *entity.Article) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	r.create(article)
+	return nil
+}
+
+// Upsert создает статью или обновляет статью ленты с тем же ключом
+func (r *InMemoryArticleRepository) Upsert(ctx context.Context, article *entity.Article) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id, exists := r.feedKeys[article.FeedID][article.Key]; exists {
+		stored := r.articles[id]
+		article.ID = stored.ID
+		article.IsRead = stored.IsRead
+		*stored = *article
+		return false, nil
+	}
+
+	r.create(article)
+	return true, nil
+}
+
+// UpsertAll сохраняет статьи ленты по одной через Upsert
+func (r *InMemoryArticleRepository) UpsertAll(ctx context.Context, articles []*entity.Article) (entity.UpsertResult, error) {
+	var result entity.UpsertResult
+	for _, article := range articles {
+		created, err := r.Upsert(ctx, article)
+		switch {
+		case err != nil:
+			result.Errors = append(result.Errors, entity.ArticleError{Key: article.Key, Err: err})
+		case created:
+			result.Created++
+		default:
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+// create сохраняет статью; вызывается под блокировкой
+func (r *InMemoryArticleRepository) create(article *entity.Article) {
 	article.ID = r.nextID
 	r.nextID++
 	r.articles[article.ID] = article
 	r.feedArticles[article.FeedID] = append(r.feedArticles[article.FeedID], article.ID)
 
-	return nil
+	if article.Key != "" {
+		if r.feedKeys[article.FeedID] == nil {
+			r.feedKeys[article.FeedID] = make(map[string]int)
+		}
+		r.feedKeys[article.FeedID][article.Key] = article.ID
+	}
 }
 
 // GetByFeedID получает все статьи для ленты
-func (r *InMemoryArticleRepository) GetByFeedID(feedID int) ([]*entity.Article, error) {
+func (r *InMemoryArticleRepository) GetByFeedID(ctx context.Context, feedID int) ([]*entity.Article, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -57,7 +108,7 @@ func (r *InMemoryArticleRepository) GetByFeedID(feedID int) ([]*entity.Article,
 }
 
 // GetAll получает все статьи
-func (r *InMemoryArticleRepository) GetAll() ([]*entity.Article, error) {
+func (r *InMemoryArticleRepository) GetAll(ctx context.Context) ([]*entity.Article, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -70,8 +121,64 @@ func (r *InMemoryArticleRepository) GetAll() ([]*entity.Article, error) {
 	return articles, nil
 }
 
+// Search ищет статьи, содержащие все слова запроса без учета регистра.
+// Совпадения в заголовке ценятся выше совпадений в тексте
+func (r *InMemoryArticleRepository) Search(ctx context.Context, query string, feedID int) ([]*entity.Article, error) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return []*entity.Article{}, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type match struct {
+		article *entity.Article
+		score   int
+	}
+	var matches []match
+	for _, article := range r.articles {
+		if feedID > 0 && article.FeedID != feedID {
+			continue
+		}
+
+		title := strings.ToLower(article.Title)
+		content := strings.ToLower(article.Content)
+		score := 0
+		for _, term := range terms {
+			inTitle := strings.Count(title, term)
+			inContent := strings.Count(content, term)
+			if inTitle == 0 && inContent == 0 {
+				score = 0
+				break
+			}
+			score += 10*inTitle + inContent
+		}
+		if score == 0 {
+			continue
+		}
+
+		articleCopy := *article
+		matches = append(matches, match{article: &articleCopy, score: score})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].article.ID > matches[j].article.ID
+	})
+
+	articles := make([]*entity.Article, 0, len(matches))
+	for _, m := range matches {
+		articles = append(articles, m.article)
+	}
+
+	return articles, nil
+}
+
 // MarkAsRead помечает статью как прочитанную
-func (r *InMemoryArticleRepository) MarkAsRead(articleID int) error {
+func (r *InMemoryArticleRepository) MarkAsRead(ctx context.Context, articleID int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -83,4 +190,3 @@ func (r *InMemoryArticleRepository) MarkAsRead(articleID int) error {
 	article.IsRead = true
 	return nil
 }
-
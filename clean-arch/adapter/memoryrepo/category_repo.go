@@ -0,0 +1,197 @@
+package memoryrepo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// InMemoryCategoryRepository реализует CategoryRepository в памяти
+type InMemoryCategoryRepository struct {
+	categories map[int]*entity.Category
+	names      map[string]int
+	feeds      map[int]map[int]bool // категории каждой ленты
+	mu         sync.RWMutex
+	nextID     int
+}
+
+// NewInMemoryCategoryRepository создает новый экземпляр InMemoryCategoryRepository
+func NewInMemoryCategoryRepository() *InMemoryCategoryRepository {
+	return &InMemoryCategoryRepository{
+		categories: make(map[int]*entity.Category),
+		names:      make(map[string]int),
+		feeds:      make(map[int]map[int]bool),
+		nextID:     1,
+	}
+}
+
+// Create создает новую категорию
+func (r *InMemoryCategoryRepository) Create(ctx context.Context, category *entity.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.names[category.Name]; exists {
+		return fmt.Errorf("category %s already exists", category.Name)
+	}
+
+	category.ID = r.nextID
+	r.nextID++
+	categoryCopy := *category
+	r.categories[category.ID] = &categoryCopy
+	r.names[category.Name] = category.ID
+
+	return nil
+}
+
+// GetByName получает категорию по названию
+func (r *InMemoryCategoryRepository) GetByName(ctx context.Context, name string) (*entity.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, exists := r.names[name]
+	if !exists {
+		return nil, nil
+	}
+
+	category := *r.categories[id]
+	return &category, nil
+}
+
+// GetByID получает категорию по ID
+func (r *InMemoryCategoryRepository) GetByID(ctx context.Context, id int) (*entity.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, exists := r.categories[id]
+	if !exists {
+		return nil, nil
+	}
+
+	categoryCopy := *category
+	return &categoryCopy, nil
+}
+
+// GetAll получает все категории, упорядоченные по названию
+func (r *InMemoryCategoryRepository) GetAll(ctx context.Context) ([]*entity.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make([]*entity.Category, 0, len(r.categories))
+	for _, category := range r.categories {
+		categoryCopy := *category
+		categories = append(categories, &categoryCopy)
+	}
+	sortByName(categories)
+
+	return categories, nil
+}
+
+// Update переименовывает категорию
+func (r *InMemoryCategoryRepository) Update(ctx context.Context, category *entity.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.categories[category.ID]
+	if !exists {
+		return fmt.Errorf("category with ID %d not found", category.ID)
+	}
+	if id, taken := r.names[category.Name]; taken && id != category.ID {
+		return fmt.Errorf("category %s already exists", category.Name)
+	}
+
+	delete(r.names, stored.Name)
+	stored.Name = category.Name
+	r.names[stored.Name] = stored.ID
+
+	return nil
+}
+
+// Delete удаляет категорию
+func (r *InMemoryCategoryRepository) Delete(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	category, exists := r.categories[id]
+	if !exists {
+		return fmt.Errorf("category with ID %d not found", id)
+	}
+
+	delete(r.names, category.Name)
+	delete(r.categories, id)
+	for _, categories := range r.feeds {
+		delete(categories, id)
+	}
+
+	return nil
+}
+
+// AssignFeed добавляет ленту в категорию
+func (r *InMemoryCategoryRepository) AssignFeed(ctx context.Context, feedID, categoryID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.categories[categoryID]; !exists {
+		return fmt.Errorf("category with ID %d not found", categoryID)
+	}
+
+	if r.feeds[feedID] == nil {
+		r.feeds[feedID] = make(map[int]bool)
+	}
+	r.feeds[feedID][categoryID] = true
+
+	return nil
+}
+
+// UnassignFeed убирает ленту из категории
+func (r *InMemoryCategoryRepository) UnassignFeed(ctx context.Context, feedID, categoryID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.feeds[feedID][categoryID] {
+		return fmt.Errorf("feed %d is not in category %d", feedID, categoryID)
+	}
+	delete(r.feeds[feedID], categoryID)
+
+	return nil
+}
+
+// GetByFeedID получает категории ленты, упорядоченные по названию
+func (r *InMemoryCategoryRepository) GetByFeedID(ctx context.Context, feedID int) ([]*entity.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make([]*entity.Category, 0, len(r.feeds[feedID]))
+	for id := range r.feeds[feedID] {
+		category := *r.categories[id]
+		categories = append(categories, &category)
+	}
+	sortByName(categories)
+
+	return categories, nil
+}
+
+// GetFeedIDs получает ID лент категории по возрастанию
+func (r *InMemoryCategoryRepository) GetFeedIDs(ctx context.Context, categoryID int) ([]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	feedIDs := []int{}
+	for feedID, categories := range r.feeds {
+		if categories[categoryID] {
+			feedIDs = append(feedIDs, feedID)
+		}
+	}
+	sort.Ints(feedIDs)
+
+	return feedIDs, nil
+}
+
+// sortByName упорядочивает категории по названию
+func sortByName(categories []*entity.Category) {
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+}
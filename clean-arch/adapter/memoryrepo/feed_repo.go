@@ -1,6 +1,7 @@
 package memoryrepo
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -25,7 +26,7 @@ func NewInMemoryFeedRepository() *InMemoryFeedRepository {
 }
 
 // Create создает новую RSS-ленту
-func (r *InMemoryFeedRepository) Create(feed *entity.Feed) error {
+func (r *InMemoryFeedRepository) Create(ctx context.Context, feed *entity.Feed) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -42,7 +43,7 @@ func (r *InMemoryFeedRepository) Create(feed *entity.Feed) error {
 }
 
 // GetByURL получает ленту по URL
-func (r *InMemoryFeedRepository) GetByURL(url string) (*entity.Feed, error) {
+func (r *InMemoryFeedRepository) GetByURL(ctx context.Context, url string) (*entity.Feed, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -56,7 +57,7 @@ func (r *InMemoryFeedRepository) GetByURL(url string) (*entity.Feed, error) {
 }
 
 // GetAll получает все ленты
-func (r *InMemoryFeedRepository) GetAll() ([]*entity.Feed, error) {
+func (r *InMemoryFeedRepository) GetAll(ctx context.Context) ([]*entity.Feed, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -70,7 +71,7 @@ func (r *InMemoryFeedRepository) GetAll() ([]*entity.Feed, error) {
 }
 
 // GetByID получает ленту по ID
-func (r *InMemoryFeedRepository) GetByID(id int) (*entity.Feed, error) {
+func (r *InMemoryFeedRepository) GetByID(ctx context.Context, id int) (*entity.Feed, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -82,3 +83,36 @@ func (r *InMemoryFeedRepository) GetByID(id int) (*entity.Feed, error) {
 	feedCopy := *feed
 	return &feedCopy, nil
 }
+
+// UpdateFetched сохраняет валидаторы кэша и формат ленты
+func (r *InMemoryFeedRepository) UpdateFetched(ctx context.Context, feed *entity.Feed) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.feeds[feed.ID]
+	if !exists {
+		return fmt.Errorf("feed with ID %d not found", feed.ID)
+	}
+
+	updated := *stored
+	updated.ETag, updated.LastModified = feed.ETag, feed.LastModified
+	updated.Format, updated.FormatVersion = feed.Format, feed.FormatVersion
+	r.feeds[feed.ID] = &updated
+	return nil
+}
+
+// UpdateHealth сохраняет результаты загрузок ленты
+func (r *InMemoryFeedRepository) UpdateHealth(ctx context.Context, feedID int, health entity.FeedHealth) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	feed, exists := r.feeds[feedID]
+	if !exists {
+		return fmt.Errorf("feed with ID %d not found", feedID)
+	}
+
+	updated := *feed
+	updated.Health = health
+	r.feeds[feedID] = &updated
+	return nil
+}
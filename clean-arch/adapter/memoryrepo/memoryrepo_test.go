@@ -0,0 +1,17 @@
+package memoryrepo
+
+import (
+	"testing"
+
+	"rss-aggregator/clean-arch/adapter/repotest"
+)
+
+func TestRepositoryContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		return repotest.Repositories{
+			Feeds:      NewInMemoryFeedRepository(),
+			Articles:   NewInMemoryArticleRepository(),
+			Categories: NewInMemoryCategoryRepository(),
+		}
+	})
+}
@@ -0,0 +1,473 @@
+// Package repotest содержит контрактные тесты репозиториев entity, общие для
+// всех хранилищ: каждая реализация должна вести себя одинаково
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"rss-aggregator/clean-arch/entity"
+	"rss-aggregator/internal/backoff"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Repositories - репозитории одного хранилища
+type Repositories struct {
+	Feeds      entity.FeedRepository
+	Articles   entity.ArticleRepository
+	Categories entity.CategoryRepository
+}
+
+// Run проверяет контракт репозиториев. newRepositories должна возвращать
+// репозитории пустого хранилища при каждом вызове
+func Run(t *testing.T, newRepositories func(t *testing.T) Repositories) {
+	t.Run("Feeds", func(t *testing.T) { testFeeds(t, newRepositories(t)) })
+	t.Run("FeedHealth", func(t *testing.T) { testFeedHealth(t, newRepositories(t)) })
+	t.Run("ArticleUpsert", func(t *testing.T) { testArticleUpsert(t, newRepositories(t)) })
+	t.Run("ArticleUpsertAll", func(t *testing.T) { testArticleUpsertAll(t, newRepositories(t)) })
+	t.Run("ArticleList", func(t *testing.T) { testArticleList(t, newRepositories(t)) })
+	t.Run("ArticleSearch", func(t *testing.T) { testArticleSearch(t, newRepositories(t)) })
+	t.Run("Categories", func(t *testing.T) { testCategories(t, newRepositories(t)) })
+	t.Run("CategoryFeeds", func(t *testing.T) { testCategoryFeeds(t, newRepositories(t)) })
+}
+
+// createFeed сохраняет ленту с указанным URL
+func createFeed(t *testing.T, repos Repositories, url string) *entity.Feed {
+	feed := &entity.Feed{
+		URL:           url,
+		Title:         "Feed " + url,
+		Description:   "About " + url,
+		ETag:          `"v1"`,
+		LastModified:  "Mon, 24 Nov 2025 10:00:00 GMT",
+		Format:        "atom",
+		FormatVersion: "1.0",
+	}
+	require.NoError(t, repos.Feeds.Create(context.Background(), feed))
+	require.NotZero(t, feed.ID)
+	return feed
+}
+
+func testFeeds(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	first := createFeed(t, repos, "https://example.com/first.xml")
+	second := createFeed(t, repos, "https://example.com/second.xml")
+	assert.NotEqual(t, first.ID, second.ID)
+
+	// Повторный URL отклоняется
+	err := repos.Feeds.Create(ctx, &entity.Feed{URL: first.URL})
+	assert.Error(t, err)
+
+	got, err := repos.Feeds.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first, got)
+
+	got, err = repos.Feeds.GetByURL(ctx, second.URL)
+	require.NoError(t, err)
+	assert.Equal(t, second, got)
+
+	// Отсутствующая лента - не ошибка
+	got, err = repos.Feeds.GetByID(ctx, second.ID+100)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = repos.Feeds.GetByURL(ctx, "https://example.com/missing.xml")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	feeds, err := repos.Feeds.GetAll(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*entity.Feed{first, second}, feeds)
+
+	// Загрузка обновляет валидаторы кэша и формат
+	fetched := *first
+	fetched.ETag, fetched.LastModified = `"v2"`, ""
+	fetched.Format, fetched.FormatVersion = "rss", "2.0"
+	require.NoError(t, repos.Feeds.UpdateFetched(ctx, &fetched))
+
+	got, err = repos.Feeds.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &fetched, got)
+
+	fetched.ID = second.ID + 100
+	assert.Error(t, repos.Feeds.UpdateFetched(ctx, &fetched))
+}
+
+func testFeedHealth(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	feed := createFeed(t, repos, "https://example.com/feed.xml")
+	assert.Equal(t, entity.FeedStatusPending, feed.Health.Status())
+
+	// Неудачная загрузка учитывается вместе с HTTP-статусом
+	fetchedAt := time.Date(2025, 12, 12, 10, 0, 0, 0, time.UTC)
+	health := feed.Health
+	health.RecordFetch(fetchedAt, &entity.HTTPError{StatusCode: 404, Err: errors.New("http error: 404 Not Found")})
+	require.NoError(t, repos.Feeds.UpdateHealth(ctx, feed.ID, health))
+
+	got, err := repos.Feeds.GetByID(ctx, feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.FeedStatusFailing, got.Health.Status())
+	assert.Equal(t, 1, got.Health.ConsecutiveFailures)
+	assert.Equal(t, 404, got.Health.LastHTTPStatus)
+	assert.Equal(t, "http error: 404 Not Found", got.Health.LastError)
+	assert.Empty(t, got.Health.LastParseError)
+	assertTime(t, &fetchedAt, got.Health.LastFetchedAt)
+	assert.Nil(t, got.Health.LastSuccessAt)
+
+	// Ошибка разбора сохраняется отдельно
+	health.RecordFetch(fetchedAt.Add(time.Hour), &entity.ParseError{Err: errors.New("XML syntax error")})
+	require.NoError(t, repos.Feeds.UpdateHealth(ctx, feed.ID, health))
+
+	feeds, err := repos.Feeds.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, feeds, 1)
+	assert.Equal(t, 2, feeds[0].Health.ConsecutiveFailures)
+	assert.Equal(t, 200, feeds[0].Health.LastHTTPStatus)
+	assert.Equal(t, "XML syntax error", feeds[0].Health.LastParseError)
+
+	// Вторая неудача подряд удваивает паузу и при MaxFailures 2 отключает ленту
+	policy := entity.FetchPolicy{Interval: time.Hour, MaxDelay: 24 * time.Hour, MaxFailures: 2, Backoff: backoff.Delay}
+	scheduledAt := fetchedAt.Add(time.Hour)
+	health.ScheduleNext(scheduledAt, policy, 0)
+	require.NoError(t, repos.Feeds.UpdateHealth(ctx, feed.ID, health))
+
+	got, err = repos.Feeds.GetByID(ctx, feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.FeedStatusDisabled, got.Health.Status())
+	assertTime(t, &scheduledAt, got.Health.DisabledAt)
+	require.NotNil(t, got.Health.NextFetchAt)
+	assert.WithinRange(t, *got.Health.NextFetchAt, scheduledAt.Add(96*time.Minute), scheduledAt.Add(144*time.Minute))
+
+	// Издатель может попросить паузу длиннее, но не длиннее MaxDelay
+	requested := scheduledAt.Add(6 * time.Hour)
+	health.ScheduleNext(scheduledAt, policy, 6*time.Hour)
+	assertTime(t, &requested, health.NextFetchAt)
+	capped := scheduledAt.Add(policy.MaxDelay)
+	health.ScheduleNext(scheduledAt, policy, 48*time.Hour)
+	assertTime(t, &capped, health.NextFetchAt)
+
+	// Успешная загрузка сбрасывает счетчик неудач
+	succeededAt := fetchedAt.Add(2 * time.Hour)
+	health.RecordFetch(succeededAt, nil)
+	require.NoError(t, repos.Feeds.UpdateHealth(ctx, feed.ID, health))
+
+	got, err = repos.Feeds.GetByID(ctx, feed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.FeedStatusOK, got.Health.Status())
+	assert.Zero(t, got.Health.ConsecutiveFailures)
+	assert.Empty(t, got.Health.LastError)
+	assert.Empty(t, got.Health.LastParseError)
+	assertTime(t, &succeededAt, got.Health.LastSuccessAt)
+
+	// Отсутствующая лента - ошибка
+	assert.Error(t, repos.Feeds.UpdateHealth(ctx, feed.ID+100, health))
+}
+
+// newTestArticle создает статью ленты со всеми заполненными полями
+func newTestArticle(feedID int, key, title, content string) *entity.Article {
+	published := time.Date(2025, 11, 20, 10, 30, 0, 0, time.UTC)
+	updated := published.Add(time.Hour)
+	return &entity.Article{
+		FeedID:          feedID,
+		Key:             key,
+		GUID:            "guid-" + key,
+		Link:            "https://example.com/" + key,
+		Title:           title,
+		Content:         content,
+		RawContent:      content + "<script>alert(1)</script>",
+		ContentType:     "html",
+		Summary:         "Summary of " + title,
+		PublicationDate: &published,
+		PublishedAt:     &published,
+		UpdatedAt:       &updated,
+		Authors:         []string{"Alice", "Bob"},
+		Categories:      []string{"tech", "news"},
+		Enclosures: []entity.Enclosure{
+			{URL: "https://example.com/" + key + ".mp3", Type: "audio/mpeg", Length: 1024},
+		},
+		ImageURL:    "https://example.com/" + key + ".png",
+		BannerImage: "https://example.com/" + key + "-banner.png",
+		Extensions:  `{"_blue_shed":{"about":"https://blueshed-podcasts.com/json-feed-extension-docs"}}`,
+	}
+}
+
+// assertArticle сравнивает статьи; время сравнивается как момент, без учета зоны
+func assertArticle(t *testing.T, want, got *entity.Article) {
+	t.Helper()
+	require.NotNil(t, got)
+
+	assertTime(t, want.PublicationDate, got.PublicationDate)
+	assertTime(t, want.PublishedAt, got.PublishedAt)
+	assertTime(t, want.UpdatedAt, got.UpdatedAt)
+
+	wantCopy, gotCopy := *want, *got
+	wantCopy.PublicationDate, wantCopy.PublishedAt, wantCopy.UpdatedAt = nil, nil, nil
+	gotCopy.PublicationDate, gotCopy.PublishedAt, gotCopy.UpdatedAt = nil, nil, nil
+	assert.Equal(t, wantCopy, gotCopy)
+}
+
+func assertTime(t *testing.T, want, got *time.Time) {
+	t.Helper()
+	if want == nil {
+		assert.Nil(t, got)
+		return
+	}
+	if assert.NotNil(t, got) {
+		assert.True(t, want.Equal(*got), "expected %v, got %v", want, got)
+	}
+}
+
+func testArticleUpsert(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	feed := createFeed(t, repos, "https://example.com/feed.xml")
+	other := createFeed(t, repos, "https://example.com/other.xml")
+
+	article := newTestArticle(feed.ID, "item-1", "Original title", "Original content")
+	created, err := repos.Articles.Upsert(ctx, article)
+	require.NoError(t, err)
+	assert.True(t, created)
+	require.NotZero(t, article.ID)
+	id := article.ID
+
+	require.NoError(t, repos.Articles.MarkAsRead(ctx, id))
+
+	// Тот же ключ обновляет статью, сохраняя ID и состояние прочтения
+	changed := newTestArticle(feed.ID, "item-1", "Changed title", "Changed content")
+	changed.Authors = []string{"Carol"}
+	changed.Categories = []string{"science"}
+	changed.Enclosures = nil
+	created, err = repos.Articles.Upsert(ctx, changed)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, id, changed.ID)
+	assert.True(t, changed.IsRead)
+
+	// Тот же ключ в другой ленте - другая статья
+	elsewhere := newTestArticle(other.ID, "item-1", "Elsewhere", "")
+	created, err = repos.Articles.Upsert(ctx, elsewhere)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.NotEqual(t, id, elsewhere.ID)
+
+	articles, err := repos.Articles.GetByFeedID(ctx, feed.ID)
+	require.NoError(t, err)
+	require.Len(t, articles, 1)
+	assertArticle(t, changed, articles[0])
+
+	// Статьи без ключа не объединяются
+	for range 2 {
+		created, err := repos.Articles.Upsert(ctx, &entity.Article{FeedID: other.ID, Title: "No key"})
+		require.NoError(t, err)
+		assert.True(t, created)
+	}
+	articles, err = repos.Articles.GetByFeedID(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Len(t, articles, 3)
+
+	assert.Error(t, repos.Articles.MarkAsRead(ctx, elsewhere.ID+100))
+}
+
+func testArticleUpsertAll(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	feed := createFeed(t, repos, "https://example.com/feed.xml")
+
+	stored := newTestArticle(feed.ID, "stored", "Stored", "Old content")
+	require.NoError(t, repos.Articles.Create(ctx, stored))
+	require.NoError(t, repos.Articles.MarkAsRead(ctx, stored.ID))
+
+	result, err := repos.Articles.UpsertAll(ctx, []*entity.Article{
+		newTestArticle(feed.ID, "stored", "Stored", "New content"),
+		newTestArticle(feed.ID, "first", "First", ""),
+		newTestArticle(feed.ID, "second", "Second", ""),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.UpsertResult{Created: 2, Updated: 1}, result)
+
+	articles, err := repos.Articles.GetByFeedID(ctx, feed.ID)
+	require.NoError(t, err)
+	require.Len(t, articles, 3)
+	assert.Equal(t, stored.ID, articles[0].ID)
+	assert.Equal(t, "New content", articles[0].Content)
+	assert.True(t, articles[0].IsRead)
+	assert.Equal(t, []string{"First", "Second"}, []string{articles[1].Title, articles[2].Title})
+
+	result, err = repos.Articles.UpsertAll(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, entity.UpsertResult{}, result)
+}
+
+func testArticleList(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	feed := createFeed(t, repos, "https://example.com/feed.xml")
+	other := createFeed(t, repos, "https://example.com/other.xml")
+
+	first := newTestArticle(feed.ID, "first", "First", "One")
+	second := &entity.Article{FeedID: feed.ID, Key: "second", Title: "Second"}
+	third := newTestArticle(other.ID, "third", "Third", "Three")
+	for _, article := range []*entity.Article{first, second, third} {
+		require.NoError(t, repos.Articles.Create(ctx, article))
+	}
+
+	// Статьи ленты возвращаются в порядке добавления
+	articles, err := repos.Articles.GetByFeedID(ctx, feed.ID)
+	require.NoError(t, err)
+	require.Len(t, articles, 2)
+	assertArticle(t, first, articles[0])
+	assertArticle(t, second, articles[1])
+
+	articles, err = repos.Articles.GetByFeedID(ctx, other.ID+100)
+	require.NoError(t, err)
+	assert.Empty(t, articles)
+
+	articles, err = repos.Articles.GetAll(ctx)
+	require.NoError(t, err)
+	ids := make([]int, 0, len(articles))
+	for _, article := range articles {
+		ids = append(ids, article.ID)
+	}
+	assert.ElementsMatch(t, []int{first.ID, second.ID, third.ID}, ids)
+
+	// Возвращаются копии: изменение результата не меняет хранилище
+	articles[0].Title = "Modified"
+	articles, err = repos.Articles.GetAll(ctx)
+	require.NoError(t, err)
+	for _, article := range articles {
+		assert.NotEqual(t, "Modified", article.Title)
+	}
+}
+
+func testArticleSearch(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	feed := createFeed(t, repos, "https://example.com/feed.xml")
+	other := createFeed(t, repos, "https://example.com/other.xml")
+
+	inTitle := &entity.Article{FeedID: feed.ID, Key: "title", Title: "Kubernetes release", Content: "Notes"}
+	inContent := &entity.Article{FeedID: feed.ID, Key: "content", Title: "Weekly digest", Content: "A kubernetes release is out"}
+	otherFeed := &entity.Article{FeedID: other.ID, Key: "other", Title: "Kubernetes tips", Content: "Cluster tuning"}
+	for _, article := range []*entity.Article{inTitle, inContent, otherFeed} {
+		require.NoError(t, repos.Articles.Create(ctx, article))
+	}
+
+	searchIDs := func(query string, feedID int) []int {
+		articles, err := repos.Articles.Search(ctx, query, feedID)
+		require.NoError(t, err)
+		ids := []int{}
+		for _, article := range articles {
+			ids = append(ids, article.ID)
+		}
+		return ids
+	}
+
+	// Совпадение в заголовке важнее совпадения в тексте, регистр не учитывается
+	assert.Equal(t, []int{inTitle.ID, inContent.ID}, searchIDs("KUBERNETES release", 0))
+	assert.Equal(t, []int{otherFeed.ID}, searchIDs("kubernetes tuning", 0))
+	assert.Equal(t, []int{otherFeed.ID}, searchIDs("kubernetes", other.ID))
+	assert.Empty(t, searchIDs("kubernetes missing", 0))
+	assert.Empty(t, searchIDs("   ", 0))
+}
+
+func testCategories(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	news := &entity.Category{Name: "News"}
+	tech := &entity.Category{Name: "Tech"}
+	require.NoError(t, repos.Categories.Create(ctx, tech))
+	require.NoError(t, repos.Categories.Create(ctx, news))
+	assert.NotEqual(t, news.ID, tech.ID)
+	assert.Error(t, repos.Categories.Create(ctx, &entity.Category{Name: "News"}))
+
+	got, err := repos.Categories.GetByName(ctx, "News")
+	require.NoError(t, err)
+	assert.Equal(t, news, got)
+
+	got, err = repos.Categories.GetByID(ctx, tech.ID)
+	require.NoError(t, err)
+	assert.Equal(t, tech, got)
+
+	got, err = repos.Categories.GetByName(ctx, "Missing")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = repos.Categories.GetByID(ctx, tech.ID+100)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Категории упорядочены по названию
+	categories, err := repos.Categories.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Category{news, tech}, categories)
+
+	// Переименование в занятое название отклоняется, в свое же - нет
+	assert.Error(t, repos.Categories.Update(ctx, &entity.Category{ID: tech.ID, Name: "News"}))
+	require.NoError(t, repos.Categories.Update(ctx, &entity.Category{ID: tech.ID, Name: "Tech"}))
+	require.NoError(t, repos.Categories.Update(ctx, &entity.Category{ID: tech.ID, Name: "Science"}))
+	assert.Error(t, repos.Categories.Update(ctx, &entity.Category{ID: tech.ID + 100, Name: "Other"}))
+
+	got, err = repos.Categories.GetByName(ctx, "Science")
+	require.NoError(t, err)
+	assert.Equal(t, &entity.Category{ID: tech.ID, Name: "Science"}, got)
+
+	got, err = repos.Categories.GetByName(ctx, "Tech")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	require.NoError(t, repos.Categories.Delete(ctx, news.ID))
+	assert.Error(t, repos.Categories.Delete(ctx, news.ID))
+
+	categories, err = repos.Categories.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Category{{ID: tech.ID, Name: "Science"}}, categories)
+}
+
+func testCategoryFeeds(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	first := createFeed(t, repos, "https://example.com/first.xml")
+	second := createFeed(t, repos, "https://example.com/second.xml")
+
+	news := &entity.Category{Name: "News"}
+	tech := &entity.Category{Name: "Tech"}
+	require.NoError(t, repos.Categories.Create(ctx, tech))
+	require.NoError(t, repos.Categories.Create(ctx, news))
+
+	require.NoError(t, repos.Categories.AssignFeed(ctx, second.ID, news.ID))
+	require.NoError(t, repos.Categories.AssignFeed(ctx, first.ID, news.ID))
+	require.NoError(t, repos.Categories.AssignFeed(ctx, first.ID, tech.ID))
+	// Повторное добавление не является ошибкой
+	require.NoError(t, repos.Categories.AssignFeed(ctx, first.ID, tech.ID))
+	assert.Error(t, repos.Categories.AssignFeed(ctx, first.ID, tech.ID+100))
+
+	feedIDs, err := repos.Categories.GetFeedIDs(ctx, news.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{first.ID, second.ID}, feedIDs)
+
+	categories, err := repos.Categories.GetByFeedID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Category{news, tech}, categories)
+
+	require.NoError(t, repos.Categories.UnassignFeed(ctx, first.ID, tech.ID))
+	assert.Error(t, repos.Categories.UnassignFeed(ctx, first.ID, tech.ID))
+
+	categories, err = repos.Categories.GetByFeedID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Category{news}, categories)
+
+	// Удаление категории убирает из нее ленты, но не сами ленты
+	require.NoError(t, repos.Categories.Delete(ctx, news.ID))
+
+	categories, err = repos.Categories.GetByFeedID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Empty(t, categories)
+
+	feedIDs, err = repos.Categories.GetFeedIDs(ctx, news.ID)
+	require.NoError(t, err)
+	assert.Empty(t, feedIDs)
+
+	feed, err := repos.Feeds.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, feed)
+}
@@ -1,6 +1,9 @@
 package adapter
 
 import (
+	"context"
+	"errors"
+
 	"rss-aggregator/clean-arch/entity"
 	"rss-aggregator/internal/rss"
 )
@@ -10,33 +13,129 @@ type RSSParserAdapter struct {
 	parser *rss.Parser
 }
 
-// NewRSSParserAdapter создает новый экземпляр RSSParserAdapter
-func NewRSSParserAdapter() *RSSParserAdapter {
+// NewRSSParserAdapter создает новый экземпляр RSSParserAdapter над parser
+func NewRSSParserAdapter(parser *rss.Parser) *RSSParserAdapter {
 	return &RSSParserAdapter{
-		parser: rss.NewParser(),
+		parser: parser,
 	}
 }
 
-// ParseFeed парсит RSS-ленту
-func (a *RSSParserAdapter) ParseFeed(url string) (*entity.ParsedFeed, error) {
-	feedInfo, err := a.parser.ParseFeed(url)
+// ParseFeed загружает и парсит RSS-ленту; загрузка прерывается при отмене ctx
+// или по таймауту парсера
+func (a *RSSParserAdapter) ParseFeed(ctx context.Context, url string) (*entity.ParsedFeed, error) {
+	return a.fetch(ctx, url, rss.CacheValidators{})
+}
+
+// ParseFeedIfModified загружает ленту условным запросом с валидаторами кэша
+// feed, так что неизменившаяся лента стоит ответа 304
+func (a *RSSParserAdapter) ParseFeedIfModified(ctx context.Context, feed *entity.Feed) (*entity.ParsedFeed, error) {
+	return a.fetch(ctx, feed.URL, rss.CacheValidators{ETag: feed.ETag, LastModified: feed.LastModified})
+}
+
+// fetch загружает ленту с валидаторами и преобразует результат в сущность
+func (a *RSSParserAdapter) fetch(ctx context.Context, url string, validators rss.CacheValidators) (*entity.ParsedFeed, error) {
+	result, err := a.parser.Fetch(ctx, url, validators)
 	if err != nil {
-		return nil, err
+		return nil, toEntityError(err)
 	}
 
 	parsedFeed := &entity.ParsedFeed{
-		Title:       feedInfo.Title,
-		Description: feedInfo.Description,
-		Items:       make([]entity.ParsedItem, 0, len(feedInfo.Items)),
+		ETag:         result.Validators.ETag,
+		LastModified: result.Validators.LastModified,
+		NotModified:  result.NotModified,
+		MaxAge:       result.MaxAge,
+	}
+	if result.NotModified {
+		return parsedFeed, nil
 	}
 
+	feedInfo := result.Feed
+	parsedFeed.Title = feedInfo.Title
+	parsedFeed.Description = feedInfo.Description
+	parsedFeed.Format = feedInfo.Format
+	parsedFeed.FormatVersion = feedInfo.FormatVersion
+	parsedFeed.Items = make([]entity.ParsedItem, 0, len(feedInfo.Items))
 	for _, item := range feedInfo.Items {
 		parsedFeed.Items = append(parsedFeed.Items, entity.ParsedItem{
+			Key:             item.Key(),
+			GUID:            item.GUID,
+			Link:            item.Link,
 			Title:           item.Title,
 			Content:         item.Content,
+			RawContent:      item.RawContent,
+			ContentType:     item.ContentType,
+			Summary:         item.Summary,
 			PublicationDate: item.PublicationDate,
+			PublishedAt:     item.Published,
+			UpdatedAt:       item.Updated,
+			Authors:         item.Authors,
+			Categories:      item.Categories,
+			Enclosures:      toEntityEnclosures(item.Enclosures),
+			ImageURL:        item.ImageURL,
+			BannerImage:     item.BannerImage,
+			Extensions:      string(item.Extensions),
 		})
 	}
 
 	return parsedFeed, nil
 }
+
+// DiscoverFeeds ищет ленты страницы: ссылки <link rel="alternate"> и
+// распространенные адреса вроде /feed и /rss.xml
+func (a *RSSParserAdapter) DiscoverFeeds(ctx context.Context, url string) ([]entity.FeedCandidate, error) {
+	found, err := a.parser.Discover(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]entity.FeedCandidate, 0, len(found))
+	for _, candidate := range found {
+		candidates = append(candidates, entity.FeedCandidate{
+			URL:   candidate.URL,
+			Title: candidate.Title,
+			Type:  candidate.Type,
+		})
+	}
+	return candidates, nil
+}
+
+// toEntityError преобразует ошибку загрузки из internal/rss в ошибки
+// entity, сохраняя ее текст
+func toEntityError(err error) error {
+	var httpErr *rss.HTTPError
+	if errors.As(err, &httpErr) {
+		return &entity.HTTPError{StatusCode: httpErr.StatusCode, RetryAfter: httpErr.RetryAfter, Err: err}
+	}
+	if rss.IsNotFeed(err) {
+		err = notFeedError{err: err}
+	}
+	var parseErr *rss.ParseError
+	if errors.As(err, &parseErr) {
+		return &entity.ParseError{Err: err}
+	}
+	return err
+}
+
+// notFeedError сохраняет текст ошибки парсера и соответствует entity.ErrNotFeed
+type notFeedError struct {
+	err error
+}
+
+func (e notFeedError) Error() string { return e.err.Error() }
+
+func (e notFeedError) Unwrap() error { return e.err }
+
+func (e notFeedError) Is(target error) bool { return target == entity.ErrNotFeed }
+
+// toEntityEnclosures преобразует вложения из internal/rss в сущности
+func toEntityEnclosures(enclosures []rss.Enclosure) []entity.Enclosure {
+	converted := make([]entity.Enclosure, 0, len(enclosures))
+	for _, enclosure := range enclosures {
+		converted = append(converted, entity.Enclosure{
+			URL:    enclosure.URL,
+			Type:   enclosure.Type,
+			Length: enclosure.Length,
+		})
+	}
+	return converted
+}
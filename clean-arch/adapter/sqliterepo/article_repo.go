@@ -0,0 +1,331 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// articleColumns - колонки, которые читает scanArticles. CLI однопользовательский,
+// поэтому состояние прочтения хранится в articles.is_read, а не в user_articles
+const articleColumns = "a.id, a.feed_id, COALESCE(a.guid_or_hash, ''), COALESCE(a.guid, ''), COALESCE(a.link, ''), " +
+	"a.title, COALESCE(a.content, ''), COALESCE(a.raw_content, ''), COALESCE(a.content_type, ''), COALESCE(a.summary, ''), a.publication_date, " +
+	"a.published_at, a.updated_at, COALESCE(a.authors, ''), COALESCE(a.image_url, ''), COALESCE(a.banner_image, ''), " +
+	"COALESCE(a.extensions, ''), COALESCE(a.is_read, FALSE)"
+
+// SQLiteArticleRepository реализует ArticleRepository в SQLite
+type SQLiteArticleRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteArticleRepository создает новый экземпляр SQLiteArticleRepository
+func NewSQLiteArticleRepository(db *sql.DB) *SQLiteArticleRepository {
+	return &SQLiteArticleRepository{db: db}
+}
+
+// Create создает новую статью
+func (r *SQLiteArticleRepository) Create(ctx context.Context, article *entity.Article) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		return insertArticle(ctx, tx, article)
+	})
+}
+
+// Upsert создает статью или обновляет статью ленты с тем же ключом,
+// сохраняя ее ID и состояние прочтения
+func (r *SQLiteArticleRepository) Upsert(ctx context.Context, article *entity.Article) (bool, error) {
+	created := false
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var (
+			id     int
+			isRead bool
+		)
+		err := tx.QueryRowContext(
+			ctx,
+			"SELECT id, COALESCE(is_read, FALSE) FROM articles WHERE feed_id = ? AND guid_or_hash = ?",
+			article.FeedID, article.Key,
+		).Scan(&id, &isRead)
+		if err == sql.ErrNoRows {
+			created = true
+			return insertArticle(ctx, tx, article)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE articles SET guid = ?, link = ?, title = ?, content = ?, raw_content = ?, content_type = ?,
+				summary = ?, publication_date = ?, published_at = ?, updated_at = ?, authors = ?, image_url = ?,
+				banner_image = ?, extensions = ?
+			WHERE id = ?`,
+			nullIfEmpty(article.GUID), nullIfEmpty(article.Link), article.Title, article.Content,
+			nullIfEmpty(article.RawContent), nullIfEmpty(article.ContentType), nullIfEmpty(article.Summary), article.PublicationDate,
+			article.PublishedAt, article.UpdatedAt, nullIfEmpty(strings.Join(article.Authors, "\n")),
+			nullIfEmpty(article.ImageURL), nullIfEmpty(article.BannerImage), nullIfEmpty(article.Extensions), id,
+		)
+		if err != nil {
+			return err
+		}
+
+		article.ID = id
+		article.IsRead = isRead
+		return replaceMetadata(ctx, tx, article)
+	})
+
+	return created, err
+}
+
+// UpsertAll сохраняет статьи ленты; каждая статья сохраняется в своей
+// транзакции, поэтому ошибка одной статьи не откатывает остальные
+func (r *SQLiteArticleRepository) UpsertAll(ctx context.Context, articles []*entity.Article) (entity.UpsertResult, error) {
+	var result entity.UpsertResult
+	for _, article := range articles {
+		created, err := r.Upsert(ctx, article)
+		switch {
+		case ctx.Err() != nil:
+			return result, ctx.Err()
+		case err != nil:
+			result.Errors = append(result.Errors, entity.ArticleError{Key: article.Key, Err: err})
+		case created:
+			result.Created++
+		default:
+			result.Updated++
+		}
+	}
+
+	return result, nil
+}
+
+// inTx выполняет fn в транзакции и фиксирует ее, если fn не вернула ошибку
+func (r *SQLiteArticleRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertArticle сохраняет статью вместе с ее категориями и вложениями
+func insertArticle(ctx context.Context, tx *sql.Tx, article *entity.Article) error {
+	err := tx.QueryRowContext(
+		ctx,
+		`INSERT INTO articles (feed_id, guid_or_hash, guid, link, title, content, raw_content, content_type, summary,
+			publication_date, published_at, updated_at, authors, image_url, banner_image, extensions, is_read)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`,
+		article.FeedID, nullIfEmpty(article.Key), nullIfEmpty(article.GUID), nullIfEmpty(article.Link),
+		article.Title, article.Content, nullIfEmpty(article.RawContent), nullIfEmpty(article.ContentType), nullIfEmpty(article.Summary),
+		article.PublicationDate, article.PublishedAt, article.UpdatedAt,
+		nullIfEmpty(strings.Join(article.Authors, "\n")), nullIfEmpty(article.ImageURL),
+		nullIfEmpty(article.BannerImage), nullIfEmpty(article.Extensions), article.IsRead,
+	).Scan(&article.ID)
+	if err != nil {
+		return err
+	}
+
+	return replaceMetadata(ctx, tx, article)
+}
+
+// replaceMetadata заменяет категории и вложения статьи
+func replaceMetadata(ctx context.Context, tx *sql.Tx, article *entity.Article) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM article_tags WHERE article_id = ?", article.ID); err != nil {
+		return err
+	}
+	for _, tag := range article.Categories {
+		_, err := tx.ExecContext(ctx, "INSERT INTO article_tags (article_id, tag) VALUES (?, ?) ON CONFLICT DO NOTHING", article.ID, tag)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM article_enclosures WHERE article_id = ?", article.ID); err != nil {
+		return err
+	}
+	for _, enclosure := range article.Enclosures {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO article_enclosures (article_id, url, type, length) VALUES (?, ?, ?, ?)",
+			article.ID, enclosure.URL, nullIfEmpty(enclosure.Type),
+			sql.NullInt64{Int64: enclosure.Length, Valid: enclosure.Length > 0},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByFeedID получает все статьи для ленты в порядке добавления
+func (r *SQLiteArticleRepository) GetByFeedID(ctx context.Context, feedID int) ([]*entity.Article, error) {
+	return r.queryArticles(ctx, "SELECT "+articleColumns+" FROM articles a WHERE a.feed_id = ? ORDER BY a.id", feedID)
+}
+
+// GetAll получает все статьи в порядке добавления
+func (r *SQLiteArticleRepository) GetAll(ctx context.Context) ([]*entity.Article, error) {
+	return r.queryArticles(ctx, "SELECT "+articleColumns+" FROM articles a ORDER BY a.id")
+}
+
+// Search ищет статьи по полнотекстовому индексу articles_fts. Каждое слово
+// запроса ищется как префикс, а совпадения в заголовке ценятся выше
+func (r *SQLiteArticleRepository) Search(ctx context.Context, query string, feedID int) ([]*entity.Article, error) {
+	match := matchQuery(query)
+	if match == "" {
+		return []*entity.Article{}, nil
+	}
+
+	conditions := "articles_fts MATCH ?"
+	args := []any{match}
+	if feedID > 0 {
+		conditions += " AND a.feed_id = ?"
+		args = append(args, feedID)
+	}
+
+	return r.queryArticles(
+		ctx,
+		"SELECT "+articleColumns+" FROM articles_fts JOIN articles a ON a.id = articles_fts.rowid WHERE "+
+			conditions+" ORDER BY bm25(articles_fts, 10.0, 1.0), a.id DESC",
+		args...,
+	)
+}
+
+// matchQuery превращает запрос в выражение FTS5, где каждое слово взято в
+// кавычки, чтобы операторы и кавычки во вводе не ломали синтаксис
+func matchQuery(query string) string {
+	terms := strings.Fields(query)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// MarkAsRead помечает статью как прочитанную
+func (r *SQLiteArticleRepository) MarkAsRead(ctx context.Context, articleID int) error {
+	result, err := r.db.ExecContext(ctx, "UPDATE articles SET is_read = TRUE WHERE id = ?", articleID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("article with ID %d not found", articleID)
+	}
+
+	return nil
+}
+
+// queryArticles выполняет запрос, выбирающий articleColumns, и загружает
+// категории и вложения найденных статей
+func (r *SQLiteArticleRepository) queryArticles(ctx context.Context, query string, args ...any) ([]*entity.Article, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	articles := []*entity.Article{}
+	for rows.Next() {
+		var (
+			article entity.Article
+			authors string
+		)
+		err := rows.Scan(
+			&article.ID, &article.FeedID, &article.Key, &article.GUID, &article.Link, &article.Title,
+			&article.Content, &article.RawContent, &article.ContentType, &article.Summary, &article.PublicationDate,
+			&article.PublishedAt, &article.UpdatedAt, &authors, &article.ImageURL, &article.BannerImage,
+			&article.Extensions, &article.IsRead,
+		)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if authors != "" {
+			article.Authors = strings.Split(authors, "\n")
+		}
+		articles = append(articles, &article)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadMetadata(ctx, articles); err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// loadMetadata заполняет категории и вложения статей в порядке их сохранения
+func (r *SQLiteArticleRepository) loadMetadata(ctx context.Context, articles []*entity.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	index := make(map[int]*entity.Article, len(articles))
+	args := make([]any, 0, len(articles))
+	for _, article := range articles {
+		index[article.ID] = article
+		args = append(args, article.ID)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		fmt.Sprintf("SELECT article_id, tag FROM article_tags WHERE article_id IN (%s) ORDER BY rowid", placeholders),
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var (
+			articleID int
+			tag       string
+		)
+		if err := rows.Scan(&articleID, &tag); err != nil {
+			rows.Close()
+			return err
+		}
+		article := index[articleID]
+		article.Categories = append(article.Categories, tag)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = r.db.QueryContext(
+		ctx,
+		fmt.Sprintf("SELECT article_id, url, COALESCE(type, ''), COALESCE(length, 0) FROM article_enclosures WHERE article_id IN (%s) ORDER BY id", placeholders),
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			articleID int
+			enclosure entity.Enclosure
+		)
+		if err := rows.Scan(&articleID, &enclosure.URL, &enclosure.Type, &enclosure.Length); err != nil {
+			return err
+		}
+		article := index[articleID]
+		article.Enclosures = append(article.Enclosures, enclosure)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,222 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// SQLiteCategoryRepository реализует CategoryRepository в SQLite.
+// Категории CLI не принадлежат пользователям сервера, поэтому у них user_id IS NULL;
+// ограничение UNIQUE (user_id, name) не действует для NULL, и названия проверяются здесь
+type SQLiteCategoryRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteCategoryRepository создает новый экземпляр SQLiteCategoryRepository
+func NewSQLiteCategoryRepository(db *sql.DB) *SQLiteCategoryRepository {
+	return &SQLiteCategoryRepository{db: db}
+}
+
+// Create создает новую категорию
+func (r *SQLiteCategoryRepository) Create(ctx context.Context, category *entity.Category) error {
+	existing, err := r.GetByName(ctx, category.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("category %s already exists", category.Name)
+	}
+
+	return r.db.QueryRowContext(
+		ctx,
+		"INSERT INTO categories (name) VALUES (?) RETURNING id",
+		category.Name,
+	).Scan(&category.ID)
+}
+
+// GetByID получает категорию по ID
+func (r *SQLiteCategoryRepository) GetByID(ctx context.Context, id int) (*entity.Category, error) {
+	return scanCategory(r.db.QueryRowContext(
+		ctx,
+		"SELECT id, name FROM categories WHERE id = ? AND user_id IS NULL",
+		id,
+	))
+}
+
+// GetByName получает категорию по названию
+func (r *SQLiteCategoryRepository) GetByName(ctx context.Context, name string) (*entity.Category, error) {
+	return scanCategory(r.db.QueryRowContext(
+		ctx,
+		"SELECT id, name FROM categories WHERE name = ? AND user_id IS NULL",
+		name,
+	))
+}
+
+// GetAll получает все категории, упорядоченные по названию
+func (r *SQLiteCategoryRepository) GetAll(ctx context.Context) ([]*entity.Category, error) {
+	return r.queryCategories(ctx, "SELECT id, name FROM categories WHERE user_id IS NULL ORDER BY name")
+}
+
+// Update переименовывает категорию
+func (r *SQLiteCategoryRepository) Update(ctx context.Context, category *entity.Category) error {
+	existing, err := r.GetByName(ctx, category.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != category.ID {
+		return fmt.Errorf("category %s already exists", category.Name)
+	}
+
+	return r.execOne(
+		ctx,
+		fmt.Errorf("category with ID %d not found", category.ID),
+		"UPDATE categories SET name = ? WHERE id = ? AND user_id IS NULL",
+		category.Name, category.ID,
+	)
+}
+
+// Delete удаляет категорию вместе с ее привязками к лентам
+func (r *SQLiteCategoryRepository) Delete(ctx context.Context, id int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM categories WHERE id = ? AND user_id IS NULL", id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("category with ID %d not found", id)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM feed_categories WHERE category_id = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AssignFeed добавляет ленту в категорию
+func (r *SQLiteCategoryRepository) AssignFeed(ctx context.Context, feedID, categoryID int) error {
+	category, err := r.GetByID(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	if category == nil {
+		return fmt.Errorf("category with ID %d not found", categoryID)
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		"INSERT INTO feed_categories (feed_id, category_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+		feedID, categoryID,
+	)
+	return err
+}
+
+// UnassignFeed убирает ленту из категории
+func (r *SQLiteCategoryRepository) UnassignFeed(ctx context.Context, feedID, categoryID int) error {
+	return r.execOne(
+		ctx,
+		fmt.Errorf("feed %d is not in category %d", feedID, categoryID),
+		"DELETE FROM feed_categories WHERE feed_id = ? AND category_id = ?",
+		feedID, categoryID,
+	)
+}
+
+// GetByFeedID получает категории ленты, упорядоченные по названию
+func (r *SQLiteCategoryRepository) GetByFeedID(ctx context.Context, feedID int) ([]*entity.Category, error) {
+	return r.queryCategories(
+		ctx,
+		`SELECT c.id, c.name FROM categories c
+		JOIN feed_categories fc ON fc.category_id = c.id
+		WHERE fc.feed_id = ? AND c.user_id IS NULL
+		ORDER BY c.name`,
+		feedID,
+	)
+}
+
+// GetFeedIDs получает ID лент категории по возрастанию
+func (r *SQLiteCategoryRepository) GetFeedIDs(ctx context.Context, categoryID int) ([]int, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT feed_id FROM feed_categories WHERE category_id = ? ORDER BY feed_id",
+		categoryID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	feedIDs := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		feedIDs = append(feedIDs, id)
+	}
+
+	return feedIDs, rows.Err()
+}
+
+// queryCategories выполняет запрос, выбирающий id и name категорий
+func (r *SQLiteCategoryRepository) queryCategories(ctx context.Context, query string, args ...any) ([]*entity.Category, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []*entity.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+// execOne выполняет запрос и возвращает notFound, если он не изменил ни одной строки
+func (r *SQLiteCategoryRepository) execOne(ctx context.Context, notFound error, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+
+	return nil
+}
+
+// scanCategory читает строку с id и name категории; отсутствие строки дает nil
+func scanCategory(row interface{ Scan(dest ...any) error }) (*entity.Category, error) {
+	var category entity.Category
+	err := row.Scan(&category.ID, &category.Name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
@@ -0,0 +1,138 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// feedColumns - колонки, которые читает scanFeed
+const feedColumns = "id, url, COALESCE(title, ''), COALESCE(description, ''), COALESCE(etag, ''), COALESCE(last_modified, ''), " +
+	"COALESCE(format, ''), COALESCE(format_version, ''), " +
+	"consecutive_failures, COALESCE(last_http_status, 0), COALESCE(last_error, ''), COALESCE(last_parse_error, ''), " +
+	"last_fetched_at, last_success_at, next_fetch_at, disabled_at"
+
+// SQLiteFeedRepository реализует FeedRepository в SQLite
+type SQLiteFeedRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteFeedRepository создает новый экземпляр SQLiteFeedRepository
+func NewSQLiteFeedRepository(db *sql.DB) *SQLiteFeedRepository {
+	return &SQLiteFeedRepository{db: db}
+}
+
+// Create создает новую RSS-ленту
+func (r *SQLiteFeedRepository) Create(ctx context.Context, feed *entity.Feed) error {
+	existing, err := r.GetByURL(ctx, feed.URL)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("feed with URL %s already exists", feed.URL)
+	}
+
+	return r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO feeds (url, title, description, etag, last_modified, format, format_version)
+		VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING id`,
+		feed.URL, nullIfEmpty(feed.Title), nullIfEmpty(feed.Description),
+		nullIfEmpty(feed.ETag), nullIfEmpty(feed.LastModified),
+		nullIfEmpty(feed.Format), nullIfEmpty(feed.FormatVersion),
+	).Scan(&feed.ID)
+}
+
+// GetByURL получает ленту по URL
+func (r *SQLiteFeedRepository) GetByURL(ctx context.Context, url string) (*entity.Feed, error) {
+	return scanFeed(r.db.QueryRowContext(ctx, "SELECT "+feedColumns+" FROM feeds WHERE url = ?", url))
+}
+
+// GetAll получает все ленты, упорядоченные по ID
+func (r *SQLiteFeedRepository) GetAll(ctx context.Context) ([]*entity.Feed, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+feedColumns+" FROM feeds ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	feeds := []*entity.Feed{}
+	for rows.Next() {
+		feed, err := scanFeed(rows)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, feed)
+	}
+
+	return feeds, rows.Err()
+}
+
+// GetByID получает ленту по ID
+func (r *SQLiteFeedRepository) GetByID(ctx context.Context, id int) (*entity.Feed, error) {
+	return scanFeed(r.db.QueryRowContext(ctx, "SELECT "+feedColumns+" FROM feeds WHERE id = ?", id))
+}
+
+// UpdateFetched сохраняет валидаторы кэша и формат ленты
+func (r *SQLiteFeedRepository) UpdateFetched(ctx context.Context, feed *entity.Feed) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		"UPDATE feeds SET etag = ?, last_modified = ?, format = ?, format_version = ? WHERE id = ?",
+		nullIfEmpty(feed.ETag), nullIfEmpty(feed.LastModified),
+		nullIfEmpty(feed.Format), nullIfEmpty(feed.FormatVersion), feed.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkUpdated(result, feed.ID)
+}
+
+// UpdateHealth сохраняет результаты загрузок ленты
+func (r *SQLiteFeedRepository) UpdateHealth(ctx context.Context, feedID int, health entity.FeedHealth) error {
+	status := sql.NullInt64{Int64: int64(health.LastHTTPStatus), Valid: health.LastHTTPStatus != 0}
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE feeds SET consecutive_failures = ?, last_http_status = ?, last_error = ?, last_parse_error = ?,
+		last_fetched_at = ?, last_success_at = ?, next_fetch_at = ?, disabled_at = ? WHERE id = ?`,
+		health.ConsecutiveFailures, status, nullIfEmpty(health.LastError), nullIfEmpty(health.LastParseError),
+		health.LastFetchedAt, health.LastSuccessAt, health.NextFetchAt, health.DisabledAt, feedID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkUpdated(result, feedID)
+}
+
+// checkUpdated возвращает ошибку, если запрос не изменил ленту с таким ID
+func checkUpdated(result sql.Result, feedID int) error {
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return fmt.Errorf("feed with ID %d not found", feedID)
+	}
+	return nil
+}
+
+// scanFeed читает строку с колонками feedColumns; отсутствие строки дает nil
+func scanFeed(row interface{ Scan(dest ...any) error }) (*entity.Feed, error) {
+	var feed entity.Feed
+	health := &feed.Health
+	err := row.Scan(
+		&feed.ID, &feed.URL, &feed.Title, &feed.Description, &feed.ETag, &feed.LastModified, &feed.Format, &feed.FormatVersion,
+		&health.ConsecutiveFailures, &health.LastHTTPStatus, &health.LastError, &health.LastParseError,
+		&health.LastFetchedAt, &health.LastSuccessAt, &health.NextFetchAt, &health.DisabledAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &feed, nil
+}
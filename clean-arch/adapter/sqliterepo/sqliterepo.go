@@ -0,0 +1,36 @@
+// Package sqliterepo реализует репозитории на SQLite-базе со схемой сервера,
+// поэтому ленты, статьи и категории CLI сохраняются между запусками
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"rss-aggregator/internal/database"
+)
+
+// Open открывает файл SQLite и применяет к нему встроенные миграции схемы.
+// Путь может начинаться со схемы sqlite://; базы других типов не поддерживаются
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	db, err := database.New(path)
+	if err != nil {
+		return nil, err
+	}
+	if db.Driver() != "sqlite" {
+		db.Close()
+		return nil, fmt.Errorf("%s is not a SQLite database", path)
+	}
+
+	if _, err := db.Migrate(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	return db.SQL(), nil
+}
+
+// nullIfEmpty сохраняет пустую строку как NULL, как это делает сервер
+func nullIfEmpty(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,71 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"rss-aggregator/clean-arch/adapter/repotest"
+	"rss-aggregator/clean-arch/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestDB opens a migrated database in a temporary file
+func openTestDB(t *testing.T, path string) *sql.DB {
+	db, err := Open(context.Background(), path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestRepositoryContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db := openTestDB(t, filepath.Join(t.TempDir(), "test.db"))
+		return repotest.Repositories{
+			Feeds:      NewSQLiteFeedRepository(db),
+			Articles:   NewSQLiteArticleRepository(db),
+			Categories: NewSQLiteCategoryRepository(db),
+		}
+	})
+}
+
+func TestPersistence(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := Open(ctx, path)
+	require.NoError(t, err)
+
+	feed := &entity.Feed{URL: "https://example.com/feed.xml", Title: "Feed"}
+	require.NoError(t, NewSQLiteFeedRepository(db).Create(ctx, feed))
+	article := &entity.Article{FeedID: feed.ID, Key: "item", Title: "Article"}
+	require.NoError(t, NewSQLiteArticleRepository(db).Create(ctx, article))
+	category := &entity.Category{Name: "News"}
+	require.NoError(t, NewSQLiteCategoryRepository(db).Create(ctx, category))
+	require.NoError(t, NewSQLiteCategoryRepository(db).AssignFeed(ctx, feed.ID, category.ID))
+	require.NoError(t, db.Close())
+
+	// Reopening applies no migrations twice and finds the stored data
+	db = openTestDB(t, path)
+
+	feeds, err := NewSQLiteFeedRepository(db).GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Feed{feed}, feeds)
+
+	articles, err := NewSQLiteArticleRepository(db).GetByFeedID(ctx, feed.ID)
+	require.NoError(t, err)
+	require.Len(t, articles, 1)
+	assert.Equal(t, "Article", articles[0].Title)
+
+	feedIDs, err := NewSQLiteCategoryRepository(db).GetFeedIDs(ctx, category.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{feed.ID}, feedIDs)
+}
+
+func TestOpen_RejectsOtherDatabases(t *testing.T) {
+	_, err := Open(context.Background(), "mysql://localhost/rss")
+	assert.Error(t, err)
+}
@@ -0,0 +1,248 @@
+package storerepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rss-aggregator/clean-arch/entity"
+	"rss-aggregator/internal/database"
+)
+
+// pageSize - число статей, которое GetAll и Search читают из хранилища за один запрос
+const pageSize = 100
+
+// StoreArticleRepository реализует ArticleRepository над статьями лент пользователя
+// и его состоянием прочтения
+type StoreArticleRepository struct {
+	store  database.Store
+	userID int
+}
+
+// NewStoreArticleRepository создает новый экземпляр StoreArticleRepository для пользователя
+func NewStoreArticleRepository(store database.Store, userID int) *StoreArticleRepository {
+	return &StoreArticleRepository{store: store, userID: userID}
+}
+
+// Create сохраняет статью так же, как Upsert: хранилище не допускает двух
+// статей ленты с одним ключом
+func (r *StoreArticleRepository) Create(ctx context.Context, article *entity.Article) error {
+	_, err := r.Upsert(ctx, article)
+	return err
+}
+
+// Upsert создает статью или обновляет статью ленты с тем же ключом. Хранилище
+// не возвращает ID сохраненных статей, поэтому article.ID не заполняется
+func (r *StoreArticleRepository) Upsert(ctx context.Context, article *entity.Article) (bool, error) {
+	result, err := r.UpsertAll(ctx, []*entity.Article{article})
+	if err != nil {
+		return false, err
+	}
+	if len(result.Errors) > 0 {
+		return false, result.Errors[0].Err
+	}
+
+	return result.Created > 0, nil
+}
+
+// UpsertAll сохраняет статьи каждой ленты одной транзакцией хранилища.
+// Как и в Upsert, ID статей не заполняются
+func (r *StoreArticleRepository) UpsertAll(ctx context.Context, articles []*entity.Article) (entity.UpsertResult, error) {
+	var (
+		result  entity.UpsertResult
+		feedIDs []int
+	)
+	byFeed := make(map[int][]database.NewArticle)
+	for _, article := range articles {
+		if _, seen := byFeed[article.FeedID]; !seen {
+			feedIDs = append(feedIDs, article.FeedID)
+		}
+		byFeed[article.FeedID] = append(byFeed[article.FeedID], toNewArticle(article))
+	}
+
+	for _, feedID := range feedIDs {
+		items := byFeed[feedID]
+		ingested, err := r.store.IngestFeed(ctx, feedID, items)
+		if err != nil {
+			return result, err
+		}
+
+		result.Created += ingested.Inserted
+		result.Updated += ingested.Updated
+		for _, itemErr := range ingested.Errors {
+			result.Errors = append(result.Errors, entity.ArticleError{Key: itemErr.Key, Err: itemErr.Err})
+		}
+	}
+
+	return result, nil
+}
+
+// GetByFeedID получает статьи ленты, на которую подписан пользователь, начиная с новых
+func (r *StoreArticleRepository) GetByFeedID(ctx context.Context, feedID int) ([]*entity.Article, error) {
+	feed, err := r.store.GetUserFeed(ctx, r.userID, feedID)
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return []*entity.Article{}, nil
+	}
+
+	articles, err := r.store.GetArticlesByFeedID(ctx, r.userID, feedID)
+	if err != nil {
+		return nil, err
+	}
+	return toEntityArticles(articles), nil
+}
+
+// GetRecent получает не больше limit последних статей ленты, на которую подписан пользователь
+func (r *StoreArticleRepository) GetRecent(ctx context.Context, feedID, limit int) ([]*entity.Article, error) {
+	feed, err := r.store.GetUserFeed(ctx, r.userID, feedID)
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return []*entity.Article{}, nil
+	}
+
+	articles, err := r.store.GetRecentArticlesByFeedID(ctx, r.userID, feedID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toEntityArticles(articles), nil
+}
+
+// GetAll получает все статьи лент пользователя, начиная с новых
+func (r *StoreArticleRepository) GetAll(ctx context.Context) ([]*entity.Article, error) {
+	all := []*entity.Article{}
+	filter := database.ArticleFilter{UserID: r.userID, Limit: pageSize}
+	for {
+		articles, cursor, err := r.store.ListArticles(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, toEntityArticles(articles)...)
+		if cursor == nil {
+			return all, nil
+		}
+		filter.After = cursor
+	}
+}
+
+// Search ищет статьи лент пользователя полнотекстовым поиском хранилища,
+// начиная с наиболее релевантных
+func (r *StoreArticleRepository) Search(ctx context.Context, query string, feedID int) ([]*entity.Article, error) {
+	filter := database.SearchFilter{UserID: r.userID, Query: query, Limit: pageSize}
+	if feedID > 0 {
+		filter.FeedIDs = []int{feedID}
+	}
+
+	all := []*entity.Article{}
+	for {
+		results, total, err := r.store.SearchArticles(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			all = append(all, toEntityArticle(result.Article))
+		}
+		if len(results) == 0 || len(all) >= total {
+			return all, nil
+		}
+		filter.Offset += len(results)
+	}
+}
+
+// MarkAsRead помечает статью как прочитанную пользователем
+func (r *StoreArticleRepository) MarkAsRead(ctx context.Context, articleID int) error {
+	isRead := true
+	article, err := r.store.SetArticleState(ctx, r.userID, articleID, &isRead, nil)
+	if err != nil {
+		return err
+	}
+	if article == nil {
+		return fmt.Errorf("article with ID %d not found", articleID)
+	}
+
+	return nil
+}
+
+// List получает страницу статей лент пользователя по фильтру, начиная с новых
+func (r *StoreArticleRepository) List(ctx context.Context, filter entity.ArticleFilter) ([]*entity.Article, *entity.ArticleCursor, error) {
+	stored := database.ArticleFilter{
+		UserID:      r.userID,
+		FeedIDs:     filter.FeedIDs,
+		CategoryIDs: filter.CategoryIDs,
+		IsRead:      filter.IsRead,
+		IsStarred:   filter.IsStarred,
+		From:        filter.From,
+		To:          filter.To,
+		TitleQuery:  filter.TitleQuery,
+		Limit:       filter.Limit,
+	}
+	if filter.After != nil {
+		stored.After = &database.ArticleCursor{PublicationDate: filter.After.PublicationDate, ID: filter.After.ID}
+	}
+
+	articles, next, err := r.store.ListArticles(ctx, stored)
+	if err != nil {
+		return nil, nil, err
+	}
+	if next == nil {
+		return toEntityArticles(articles), nil, nil
+	}
+	return toEntityArticles(articles), &entity.ArticleCursor{PublicationDate: next.PublicationDate, ID: next.ID}, nil
+}
+
+// SearchPage получает страницу результатов полнотекстового поиска по статьям лент пользователя
+func (r *StoreArticleRepository) SearchPage(ctx context.Context, filter entity.SearchFilter) ([]entity.SearchResult, int, error) {
+	results, total, err := r.store.SearchArticles(ctx, database.SearchFilter{
+		UserID:  r.userID,
+		Query:   filter.Query,
+		FeedIDs: filter.FeedIDs,
+		IsRead:  filter.IsRead,
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	converted := make([]entity.SearchResult, 0, len(results))
+	for _, result := range results {
+		converted = append(converted, entity.SearchResult{
+			Article:        toEntityArticle(result.Article),
+			TitleHighlight: result.TitleHighlight,
+			Snippet:        result.Snippet,
+			Rank:           result.Rank,
+		})
+	}
+	return converted, total, nil
+}
+
+// SetState меняет отметки прочтения и избранного статьи для пользователя
+func (r *StoreArticleRepository) SetState(ctx context.Context, articleID int, isRead, isStarred *bool) (*entity.Article, error) {
+	article, err := r.store.SetArticleState(ctx, r.userID, articleID, isRead, isStarred)
+	if err != nil || article == nil {
+		return nil, err
+	}
+
+	return toEntityArticle(*article), nil
+}
+
+// MarkFeedRead помечает статьи ленты прочитанными пользователем
+func (r *StoreArticleRepository) MarkFeedRead(ctx context.Context, feedID int) (int, error) {
+	updated, err := r.store.MarkFeedArticlesRead(ctx, r.userID, feedID)
+	return int(updated), err
+}
+
+// MarkCategoryRead помечает статьи лент категории пользователя прочитанными
+func (r *StoreArticleRepository) MarkCategoryRead(ctx context.Context, categoryID int) (int, error) {
+	updated, err := r.store.MarkCategoryArticlesRead(ctx, r.userID, categoryID)
+	return int(updated), err
+}
+
+// MarkReadBefore помечает прочитанными статьи лент пользователя, опубликованные раньше before
+func (r *StoreArticleRepository) MarkReadBefore(ctx context.Context, before time.Time) (int, error) {
+	updated, err := r.store.MarkArticlesReadBefore(ctx, r.userID, before)
+	return int(updated), err
+}
@@ -0,0 +1,208 @@
+package storerepo
+
+import (
+	"context"
+	"fmt"
+
+	"rss-aggregator/clean-arch/entity"
+	"rss-aggregator/internal/database"
+)
+
+// StoreCategoryRepository реализует CategoryRepository над категориями пользователя
+type StoreCategoryRepository struct {
+	store  database.Store
+	userID int
+}
+
+// NewStoreCategoryRepository создает новый экземпляр StoreCategoryRepository для пользователя
+func NewStoreCategoryRepository(store database.Store, userID int) *StoreCategoryRepository {
+	return &StoreCategoryRepository{store: store, userID: userID}
+}
+
+// Create создает новую категорию пользователя
+func (r *StoreCategoryRepository) Create(ctx context.Context, category *entity.Category) error {
+	existing, err := r.store.GetCategoryByName(ctx, r.userID, category.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("category %s already exists", category.Name)
+	}
+
+	created, err := r.store.CreateCategory(ctx, r.userID, category.Name)
+	if err != nil {
+		return err
+	}
+
+	category.ID = created.ID
+	return nil
+}
+
+// GetByID получает категорию пользователя по ID
+func (r *StoreCategoryRepository) GetByID(ctx context.Context, id int) (*entity.Category, error) {
+	category, err := r.store.GetCategoryByID(ctx, r.userID, id)
+	if err != nil || category == nil {
+		return nil, err
+	}
+
+	return toEntityCategory(*category), nil
+}
+
+// GetByName получает категорию пользователя по названию
+func (r *StoreCategoryRepository) GetByName(ctx context.Context, name string) (*entity.Category, error) {
+	category, err := r.store.GetCategoryByName(ctx, r.userID, name)
+	if err != nil || category == nil {
+		return nil, err
+	}
+
+	return toEntityCategory(*category), nil
+}
+
+// GetAll получает все категории пользователя, упорядоченные по названию
+func (r *StoreCategoryRepository) GetAll(ctx context.Context) ([]*entity.Category, error) {
+	categories, err := r.store.ListCategories(ctx, r.userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEntityCategories(categories), nil
+}
+
+// Update переименовывает категорию пользователя
+func (r *StoreCategoryRepository) Update(ctx context.Context, category *entity.Category) error {
+	if err := r.checkOwned(ctx, category.ID); err != nil {
+		return err
+	}
+
+	existing, err := r.store.GetCategoryByName(ctx, r.userID, category.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != category.ID {
+		return fmt.Errorf("category %s already exists", category.Name)
+	}
+
+	_, err = r.store.RenameCategory(ctx, category.ID, category.Name)
+	return err
+}
+
+// Delete удаляет категорию пользователя
+func (r *StoreCategoryRepository) Delete(ctx context.Context, id int) error {
+	if err := r.checkOwned(ctx, id); err != nil {
+		return err
+	}
+
+	_, err := r.store.DeleteCategory(ctx, id)
+	return err
+}
+
+// AssignFeed добавляет ленту в категорию пользователя
+func (r *StoreCategoryRepository) AssignFeed(ctx context.Context, feedID, categoryID int) error {
+	if err := r.checkOwned(ctx, categoryID); err != nil {
+		return err
+	}
+
+	return r.store.AddFeedToCategory(ctx, feedID, categoryID)
+}
+
+// UnassignFeed убирает ленту из категории пользователя
+func (r *StoreCategoryRepository) UnassignFeed(ctx context.Context, feedID, categoryID int) error {
+	if err := r.checkOwned(ctx, categoryID); err != nil {
+		return err
+	}
+
+	removed, err := r.store.RemoveFeedFromCategory(ctx, feedID, categoryID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("feed %d is not in category %d", feedID, categoryID)
+	}
+
+	return nil
+}
+
+// GetByFeedID получает категории пользователя, в которые входит лента, упорядоченные по названию
+func (r *StoreCategoryRepository) GetByFeedID(ctx context.Context, feedID int) ([]*entity.Category, error) {
+	categories, err := r.store.GetFeedCategories(ctx, r.userID, feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEntityCategories(categories), nil
+}
+
+// GetFeedIDs получает ID лент категории пользователя по возрастанию
+func (r *StoreCategoryRepository) GetFeedIDs(ctx context.Context, categoryID int) ([]int, error) {
+	summary, err := r.store.GetCategorySummary(ctx, r.userID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		return []int{}, nil
+	}
+
+	return summary.FeedIDs, nil
+}
+
+// GetSummaries получает категории пользователя с их лентами и числом
+// непрочитанных статей, которое хранилище считает одним запросом
+func (r *StoreCategoryRepository) GetSummaries(ctx context.Context) ([]entity.CategorySummary, error) {
+	summaries, err := r.store.GetCategorySummaries(ctx, r.userID)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]entity.CategorySummary, 0, len(summaries))
+	for _, summary := range summaries {
+		converted = append(converted, toEntityCategorySummary(summary))
+	}
+	return converted, nil
+}
+
+// GetSummary получает сводку по категории пользователя или nil, если ее нет
+func (r *StoreCategoryRepository) GetSummary(ctx context.Context, id int) (*entity.CategorySummary, error) {
+	summary, err := r.store.GetCategorySummary(ctx, r.userID, id)
+	if err != nil || summary == nil {
+		return nil, err
+	}
+
+	converted := toEntityCategorySummary(*summary)
+	return &converted, nil
+}
+
+// checkOwned возвращает ошибку, если у пользователя нет категории с таким ID
+func (r *StoreCategoryRepository) checkOwned(ctx context.Context, id int) error {
+	category, err := r.store.GetCategoryByID(ctx, r.userID, id)
+	if err != nil {
+		return err
+	}
+	if category == nil {
+		return fmt.Errorf("category with ID %d not found", id)
+	}
+
+	return nil
+}
+
+// toEntityCategory преобразует категорию хранилища в сущность
+func toEntityCategory(category database.Category) *entity.Category {
+	return &entity.Category{ID: category.ID, Name: category.Name}
+}
+
+// toEntityCategorySummary преобразует сводку по категории из хранилища в сущность
+func toEntityCategorySummary(summary database.CategorySummary) entity.CategorySummary {
+	return entity.CategorySummary{
+		Category:    *toEntityCategory(summary.Category),
+		FeedIDs:     summary.FeedIDs,
+		UnreadCount: summary.UnreadCount,
+	}
+}
+
+// toEntityCategories преобразует категории хранилища в сущности
+func toEntityCategories(categories []database.Category) []*entity.Category {
+	converted := make([]*entity.Category, 0, len(categories))
+	for _, category := range categories {
+		converted = append(converted, toEntityCategory(category))
+	}
+	return converted
+}
@@ -0,0 +1,162 @@
+package storerepo
+
+import (
+	"context"
+	"fmt"
+
+	"rss-aggregator/clean-arch/entity"
+	"rss-aggregator/internal/database"
+)
+
+// StoreFeedRepository реализует FeedRepository над подписками пользователя
+type StoreFeedRepository struct {
+	store  database.Store
+	userID int
+}
+
+// NewStoreFeedRepository создает новый экземпляр StoreFeedRepository для пользователя
+func NewStoreFeedRepository(store database.Store, userID int) *StoreFeedRepository {
+	return &StoreFeedRepository{store: store, userID: userID}
+}
+
+// Create подписывает пользователя на ленту. Ленты общие, поэтому лента, которую
+// уже добавил другой пользователь, не создается заново, а получает новую подписку
+func (r *StoreFeedRepository) Create(ctx context.Context, feed *entity.Feed) error {
+	stored, err := r.store.GetFeedByURL(ctx, feed.URL)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		stored, err = r.store.CreateFeed(ctx, feed.URL, &feed.Title, &feed.Description)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Валидаторы только удешевляют следующую загрузку, поэтому ошибка их сохранения не критична
+	if feed.ETag != "" || feed.LastModified != "" {
+		_ = r.store.UpdateFeedCacheValidators(ctx, stored.ID, feed.ETag, feed.LastModified)
+	}
+
+	if feed.Format != "" {
+		if err := r.store.UpdateFeedFormat(ctx, stored.ID, feed.Format, feed.FormatVersion); err != nil {
+			return err
+		}
+	}
+
+	subscribed, err := r.store.SubscribeFeed(ctx, r.userID, stored.ID)
+	if err != nil {
+		return err
+	}
+	if !subscribed {
+		return fmt.Errorf("feed with URL %s already exists", feed.URL)
+	}
+
+	feed.ID = stored.ID
+	return nil
+}
+
+// GetByURL получает ленту по URL, если пользователь на нее подписан
+func (r *StoreFeedRepository) GetByURL(ctx context.Context, url string) (*entity.Feed, error) {
+	feed, err := r.store.GetFeedByURL(ctx, url)
+	if err != nil || feed == nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, feed.ID)
+}
+
+// GetAll получает все ленты пользователя, упорядоченные по ID
+func (r *StoreFeedRepository) GetAll(ctx context.Context) ([]*entity.Feed, error) {
+	feeds, err := r.store.ListAllFeeds(ctx, r.userID)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]*entity.Feed, 0, len(feeds))
+	for i := range feeds {
+		converted = append(converted, toEntityFeed(&feeds[i]))
+	}
+	return converted, nil
+}
+
+// GetPage получает страницу лент пользователя, упорядоченных по ID, и общее число его лент
+func (r *StoreFeedRepository) GetPage(ctx context.Context, limit, offset int) ([]*entity.Feed, int, error) {
+	feeds, err := r.store.ListFeeds(ctx, r.userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.store.CountFeeds(ctx, r.userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	converted := make([]*entity.Feed, 0, len(feeds))
+	for i := range feeds {
+		converted = append(converted, toEntityFeed(&feeds[i]))
+	}
+	return converted, total, nil
+}
+
+// Unsubscribe отписывает пользователя от ленты. Хранилище удаляет ленту вместе
+// со статьями, когда на нее больше никто не подписан
+func (r *StoreFeedRepository) Unsubscribe(ctx context.Context, feedID int) (bool, error) {
+	return r.store.UnsubscribeFeed(ctx, r.userID, feedID)
+}
+
+// GetByID получает ленту по ID, если пользователь на нее подписан
+func (r *StoreFeedRepository) GetByID(ctx context.Context, id int) (*entity.Feed, error) {
+	feed, err := r.getFeed(ctx, id)
+	if err != nil || feed == nil {
+		return nil, err
+	}
+
+	return toEntityFeed(feed), nil
+}
+
+// UpdateFetched сохраняет валидаторы кэша и формат ленты пользователя
+func (r *StoreFeedRepository) UpdateFetched(ctx context.Context, feed *entity.Feed) error {
+	if _, err := r.getOwnedFeed(ctx, feed.ID); err != nil {
+		return err
+	}
+
+	if err := r.store.UpdateFeedCacheValidators(ctx, feed.ID, feed.ETag, feed.LastModified); err != nil {
+		return err
+	}
+	return r.store.UpdateFeedFormat(ctx, feed.ID, feed.Format, feed.FormatVersion)
+}
+
+// UpdateHealth сохраняет результаты загрузок ленты пользователя. Время
+// следующего фонового обновления остается за планировщиком сервера
+func (r *StoreFeedRepository) UpdateHealth(ctx context.Context, feedID int, health entity.FeedHealth) error {
+	feed, err := r.getOwnedFeed(ctx, feedID)
+	if err != nil {
+		return err
+	}
+
+	stored := ToStoreFeedHealth(feedID, health)
+	stored.NextFetchAt = feed.Health.NextFetchAt
+	return r.store.UpdateFeedHealth(ctx, stored)
+}
+
+// getFeed получает ленту пользователя, а для AllUsers - любую ленту
+func (r *StoreFeedRepository) getFeed(ctx context.Context, id int) (*database.Feed, error) {
+	if r.userID == AllUsers {
+		return r.store.GetFeedByID(ctx, id)
+	}
+	return r.store.GetUserFeed(ctx, r.userID, id)
+}
+
+// getOwnedFeed получает ленту так же, как getFeed, и возвращает ошибку, если ее нет
+func (r *StoreFeedRepository) getOwnedFeed(ctx context.Context, id int) (*database.Feed, error) {
+	feed, err := r.getFeed(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return nil, fmt.Errorf("feed with ID %d not found", id)
+	}
+
+	return feed, nil
+}
@@ -0,0 +1,161 @@
+// Package storerepo реализует репозитории над database.Store сервера.
+// Репозитории привязаны к одному пользователю: ленты - это его подписки,
+// категории и состояние прочтения - его собственные, поэтому для каждого
+// пользователя создается свой набор репозиториев. Фоновое обновление лент
+// сервера не связано с пользователем и использует репозитории AllUsers
+package storerepo
+
+import (
+	"rss-aggregator/clean-arch/entity"
+	"rss-aggregator/internal/database"
+)
+
+// AllUsers - ID пользователя, с которым репозиторий лент видит ленты всех
+// пользователей. Настоящие ID пользователей начинаются с 1
+const AllUsers = 0
+
+// toEntityFeed преобразует ленту хранилища в сущность
+func toEntityFeed(feed *database.Feed) *entity.Feed {
+	return &entity.Feed{
+		ID:            feed.ID,
+		URL:           feed.URL,
+		Title:         deref(feed.Title),
+		Description:   deref(feed.Description),
+		ETag:          deref(feed.ETag),
+		LastModified:  deref(feed.LastModified),
+		Format:        deref(feed.Format),
+		FormatVersion: deref(feed.FormatVersion),
+		Health:        ToEntityFeedHealth(feed.Health),
+	}
+}
+
+// ToEntityFeedHealth преобразует результаты загрузок ленты из хранилища в сущность
+func ToEntityFeedHealth(health database.FeedHealth) entity.FeedHealth {
+	converted := entity.FeedHealth{
+		ConsecutiveFailures: health.ConsecutiveFailures,
+		LastError:           deref(health.LastError),
+		LastParseError:      deref(health.LastParseError),
+		LastFetchedAt:       health.LastFetchedAt,
+		LastSuccessAt:       health.LastSuccessAt,
+		NextFetchAt:         health.NextFetchAt,
+		DisabledAt:          health.DisabledAt,
+	}
+	if health.LastHTTPStatus != nil {
+		converted.LastHTTPStatus = *health.LastHTTPStatus
+	}
+	return converted
+}
+
+// ToStoreFeedHealth преобразует результаты загрузок ленты feedID в вид хранилища
+func ToStoreFeedHealth(feedID int, health entity.FeedHealth) database.FeedHealth {
+	converted := database.FeedHealth{
+		FeedID:              feedID,
+		ConsecutiveFailures: health.ConsecutiveFailures,
+		LastError:           nilIfEmpty(health.LastError),
+		LastParseError:      nilIfEmpty(health.LastParseError),
+		LastFetchedAt:       health.LastFetchedAt,
+		LastSuccessAt:       health.LastSuccessAt,
+		NextFetchAt:         health.NextFetchAt,
+		DisabledAt:          health.DisabledAt,
+	}
+	if health.LastHTTPStatus != 0 {
+		converted.LastHTTPStatus = &health.LastHTTPStatus
+	}
+	return converted
+}
+
+// toEntityArticle преобразует статью хранилища в сущность. Хранилище не
+// отдает ключ статьи, поэтому Key остается пустым
+func toEntityArticle(article database.Article) *entity.Article {
+	enclosures := make([]entity.Enclosure, 0, len(article.Enclosures))
+	for _, enclosure := range article.Enclosures {
+		converted := entity.Enclosure{URL: enclosure.URL, Type: deref(enclosure.Type)}
+		if enclosure.Length != nil {
+			converted.Length = *enclosure.Length
+		}
+		enclosures = append(enclosures, converted)
+	}
+
+	return &entity.Article{
+		ID:              article.ID,
+		FeedID:          article.FeedID,
+		GUID:            deref(article.GUID),
+		Link:            deref(article.Link),
+		Title:           article.Title,
+		Content:         deref(article.Content),
+		RawContent:      deref(article.RawContent),
+		ContentType:     deref(article.ContentType),
+		Summary:         deref(article.Summary),
+		PublicationDate: article.PublicationDate,
+		PublishedAt:     article.PublishedAt,
+		UpdatedAt:       article.UpdatedAt,
+		Authors:         article.Authors,
+		Categories:      article.Categories,
+		Enclosures:      enclosures,
+		ImageURL:        deref(article.ImageURL),
+		BannerImage:     deref(article.BannerImage),
+		Extensions:      deref(article.Extensions),
+		IsRead:          article.IsRead,
+		IsStarred:       article.IsStarred,
+	}
+}
+
+// toEntityArticles преобразует статьи хранилища в сущности
+func toEntityArticles(articles []database.Article) []*entity.Article {
+	converted := make([]*entity.Article, 0, len(articles))
+	for _, article := range articles {
+		converted = append(converted, toEntityArticle(article))
+	}
+	return converted
+}
+
+// toNewArticle преобразует сущность в статью для сохранения в хранилище
+func toNewArticle(article *entity.Article) database.NewArticle {
+	enclosures := make([]database.Enclosure, 0, len(article.Enclosures))
+	for _, enclosure := range article.Enclosures {
+		converted := database.Enclosure{URL: enclosure.URL}
+		if enclosure.Type != "" {
+			converted.Type = &enclosure.Type
+		}
+		if enclosure.Length != 0 {
+			converted.Length = &enclosure.Length
+		}
+		enclosures = append(enclosures, converted)
+	}
+
+	return database.NewArticle{
+		Key:             article.Key,
+		GUID:            article.GUID,
+		Link:            article.Link,
+		Title:           article.Title,
+		Content:         article.Content,
+		RawContent:      article.RawContent,
+		ContentType:     article.ContentType,
+		Summary:         article.Summary,
+		PublicationDate: article.PublicationDate,
+		PublishedAt:     article.PublishedAt,
+		UpdatedAt:       article.UpdatedAt,
+		Authors:         article.Authors,
+		ImageURL:        article.ImageURL,
+		BannerImage:     article.BannerImage,
+		Extensions:      article.Extensions,
+		Categories:      article.Categories,
+		Enclosures:      enclosures,
+	}
+}
+
+// deref возвращает значение строки или пустую строку для nil
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// nilIfEmpty возвращает nil для пустой строки, как ее хранит сервер
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -1,46 +1,106 @@
 package app
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
+
 	"rss-aggregator/clean-arch/adapter"
 	"rss-aggregator/clean-arch/adapter/cli"
 	"rss-aggregator/clean-arch/adapter/memoryrepo"
+	"rss-aggregator/clean-arch/adapter/sqliterepo"
+	"rss-aggregator/clean-arch/entity"
 	"rss-aggregator/clean-arch/usecase"
+	"rss-aggregator/internal/rss"
+)
+
+// Хранилища, которые можно выбрать в Config.Storage
+const (
+	StorageMemory = "memory"
+	StorageSQLite = "sqlite"
 )
 
+// Config задает настройки приложения
+type Config struct {
+	// Storage - хранилище данных: StorageMemory или StorageSQLite
+	Storage string
+	// DBPath - путь к файлу SQLite для StorageSQLite
+	DBPath string
+}
+
 // App представляет приложение RSS-агрегатора
 type App struct {
 	cli *cli.CLI
+	db  *sql.DB
 }
 
 // NewApp создает новое приложение
-func NewApp() *App {
+func NewApp(config Config) (*App, error) {
+	app := &App{}
+
 	// Инициализация репозиториев
-	feedRepo := memoryrepo.NewInMemoryFeedRepository()
-	articleRepo := memoryrepo.NewInMemoryArticleRepository()
+	var (
+		feedRepo     entity.FeedRepository
+		articleRepo  entity.ArticleRepository
+		categoryRepo entity.CategoryRepository
+	)
+	switch config.Storage {
+	case StorageMemory, "":
+		feedRepo = memoryrepo.NewInMemoryFeedRepository()
+		articleRepo = memoryrepo.NewInMemoryArticleRepository()
+		categoryRepo = memoryrepo.NewInMemoryCategoryRepository()
+	case StorageSQLite:
+		db, err := sqliterepo.Open(context.Background(), config.DBPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+		app.db = db
+		feedRepo = sqliterepo.NewSQLiteFeedRepository(db)
+		articleRepo = sqliterepo.NewSQLiteArticleRepository(db)
+		categoryRepo = sqliterepo.NewSQLiteCategoryRepository(db)
+	default:
+		return nil, fmt.Errorf("unknown storage %q", config.Storage)
+	}
 
 	// Инициализация адаптера RSS-парсера
-	rssParser := adapter.NewRSSParserAdapter()
+	rssParser := adapter.NewRSSParserAdapter(rss.NewParser())
 
 	// Инициализация use cases
 	addFeedUseCase := usecase.NewAddFeedUseCase(feedRepo, articleRepo, rssParser)
 	listFeedsUseCase := usecase.NewListFeedsUseCase(feedRepo)
 	fetchArticlesUseCase := usecase.NewFetchArticlesUseCase(feedRepo, articleRepo, rssParser)
 	listArticlesUseCase := usecase.NewListArticlesUseCase(articleRepo)
+	searchArticlesUseCase := usecase.NewSearchArticlesUseCase(articleRepo)
+	importFeedsUseCase := usecase.NewImportFeedsUseCase(feedRepo, articleRepo, categoryRepo, rssParser)
+	exportFeedsUseCase := usecase.NewExportFeedsUseCase(feedRepo, categoryRepo)
+	listCategoriesUseCase := usecase.NewListCategoriesUseCase(categoryRepo, articleRepo)
+	categorizeFeedUseCase := usecase.NewCategorizeFeedUseCase(feedRepo, categoryRepo)
 
 	// Инициализация CLI
-	cliInstance := cli.NewCLI(
+	app.cli = cli.NewCLI(
 		addFeedUseCase,
 		listFeedsUseCase,
 		fetchArticlesUseCase,
 		listArticlesUseCase,
+		searchArticlesUseCase,
+		importFeedsUseCase,
+		exportFeedsUseCase,
+		listCategoriesUseCase,
+		categorizeFeedUseCase,
 	)
 
-	return &App{
-		cli: cliInstance,
-	}
+	return app, nil
 }
 
 // Run запускает приложение
 func (a *App) Run() {
 	a.cli.Run()
 }
+
+// Close освобождает ресурсы приложения, например соединение с базой
+func (a *App) Close() error {
+	if a.db == nil {
+		return nil
+	}
+	return a.db.Close()
+}
@@ -1,11 +1,33 @@
 package main
 
 import (
+	"flag"
+	"log"
+	"os"
+
 	"rss-aggregator/clean-arch/app"
 )
 
 func main() {
-	application := app.NewApp()
+	// Флаги по умолчанию берутся из переменных окружения STORAGE и DB_PATH
+	var config app.Config
+	flag.StringVar(&config.Storage, "storage", envOr("STORAGE", app.StorageMemory), "хранилище данных: memory или sqlite")
+	flag.StringVar(&config.DBPath, "db", envOr("DB_PATH", "./rss.db"), "путь к файлу SQLite для -storage=sqlite")
+	flag.Parse()
+
+	application, err := app.NewApp(config)
+	if err != nil {
+		log.Fatalf("Failed to start: %v", err)
+	}
+	defer application.Close()
+
 	application.Run()
 }
 
+// envOr возвращает значение переменной окружения или fallback, если она не задана
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
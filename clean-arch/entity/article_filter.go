@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// ArticleCursor указывает на последнюю статью страницы в порядке (PublicationDate, ID)
+type ArticleCursor struct {
+	PublicationDate *time.Time
+	ID              int
+}
+
+// ArticleFilter описывает выборку статей из нескольких лент
+type ArticleFilter struct {
+	FeedIDs     []int
+	CategoryIDs []int
+	IsRead      *bool
+	IsStarred   *bool
+	// From - нижняя граница даты публикации включительно, To - верхняя, не включая ее
+	From *time.Time
+	To   *time.Time
+	// TitleQuery отбирает статьи, в заголовке которых есть эта строка
+	TitleQuery string
+	// After - курсор предыдущей страницы; nil для первой страницы
+	After *ArticleCursor
+	Limit int
+}
+
+// SearchFilter описывает страницу результатов полнотекстового поиска
+type SearchFilter struct {
+	Query   string
+	FeedIDs []int
+	IsRead  *bool
+	Limit   int
+	Offset  int
+}
+
+// SearchResult представляет статью, найденную по запросу
+type SearchResult struct {
+	Article *Article
+	// TitleHighlight - заголовок, в котором найденные слова отмечены маркерами
+	TitleHighlight string
+	// Snippet - фрагмент текста вокруг лучшего совпадения
+	Snippet string
+	// Rank - оценка совпадения; чем меньше, тем релевантнее
+	Rank float64
+}
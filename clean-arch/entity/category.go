@@ -0,0 +1,39 @@
+package entity
+
+// Category представляет категорию лент
+type Category struct {
+	ID   int
+	Name string
+}
+
+// CategorySummary представляет категорию с ее лентами и числом непрочитанных статей
+type CategorySummary struct {
+	Category
+	FeedIDs     []int
+	UnreadCount int
+}
+
+// Subscription представляет подписку на ленту при импорте и экспорте
+type Subscription struct {
+	URL   string
+	Title string
+	// Categories - названия категорий ленты
+	Categories []string
+}
+
+// ImportStatus описывает итог импорта одной подписки
+type ImportStatus string
+
+const (
+	ImportStatusAdded  ImportStatus = "added"
+	ImportStatusExists ImportStatus = "exists"
+	ImportStatusFailed ImportStatus = "failed"
+)
+
+// ImportResult представляет результат импорта одной подписки
+type ImportResult struct {
+	URL    string
+	Status ImportStatus
+	Feed   *Feed
+	Err    error
+}
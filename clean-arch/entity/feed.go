@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Feed представляет RSS-ленту
 type Feed struct {
@@ -8,15 +11,76 @@ type Feed struct {
 	URL         string
 	Title       string
 	Description string
+	// ETag и LastModified - валидаторы HTTP-кэша последней загрузки ленты,
+	// с которыми следующая загрузка может быть условной
+	ETag         string
+	LastModified string
+	// Format - формат источника: rss, atom или json (JSON Feed), FormatVersion - его версия
+	Format        string
+	FormatVersion string
+	Health        FeedHealth
 }
 
 // Article представляет статью из RSS-ленты
 type Article struct {
-	ID             int
-	FeedID         int
-	Title          string
-	Content        string
+	ID     int
+	FeedID int
+	Key    string
+	GUID   string
+	Link   string
+	Title  string
+	// Content - очищенный HTML или, для ContentType text, простой текст
+	Content string
+	// RawContent - текст статьи в том виде, в котором его прислала лента
+	RawContent string
+	// ContentType - разметка Content: html, xhtml или text
+	ContentType string
+	// Summary - краткое описание, если лента дает его рядом с полным текстом,
+	// всегда в HTML
+	Summary         string
 	PublicationDate *time.Time
-	IsRead         bool
+	// PublishedAt - дата публикации, указанная издателем; PublicationDate
+	// без нее берется из UpdatedAt
+	PublishedAt *time.Time
+	UpdatedAt   *time.Time
+	Authors     []string
+	Categories  []string
+	Enclosures  []Enclosure
+	ImageURL    string
+	// BannerImage - баннер статьи из JSON Feed
+	BannerImage string
+	// Extensions - расширения JSON Feed: объект JSON с полями, начинающимися с "_"
+	Extensions string
+	IsRead     bool
+	// IsStarred - отметка избранного; ее ставит пользователь сервера
+	IsStarred bool
 }
 
+// Enclosure представляет вложение статьи, например аудио подкаста
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length int64
+}
+
+// UpsertResult описывает итог сохранения статей ленты
+type UpsertResult struct {
+	Created int
+	// Updated - число уже сохраненных статей, которые были перезаписаны. Хранилище
+	// может не перезаписывать не изменившиеся статьи, тогда они здесь не учитываются
+	Updated int
+	// Errors - статьи, которые не удалось сохранить
+	Errors []ArticleError
+}
+
+// ArticleError сообщает о статье, которую не удалось сохранить
+type ArticleError struct {
+	Key string
+	Err error
+}
+
+func (e ArticleError) Error() string {
+	return fmt.Sprintf("article %s: %v", e.Key, e.Err)
+}
+
+func (e ArticleError) Unwrap() error { return e.Err }
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Состояния ленты, которые возвращает FeedHealth.Status
+const (
+	FeedStatusPending  = "pending"
+	FeedStatusOK       = "ok"
+	FeedStatusFailing  = "failing"
+	FeedStatusDisabled = "disabled"
+)
+
+// FetchPolicy задает расписание фоновых обновлений ленты
+type FetchPolicy struct {
+	// Interval - пауза между загрузками ленты, пока они удаются
+	Interval time.Duration
+	// MaxDelay ограничивает паузу после неудач и паузу, о которой просит издатель
+	MaxDelay time.Duration
+	// MaxFailures - число неудачных загрузок подряд, после которого лента отключается
+	MaxFailures int
+	// Backoff возвращает паузу после failures неудачных загрузок подряд, не
+	// длиннее limit. Если он не задан, пауза после неудач равна Interval
+	Backoff func(base, limit time.Duration, failures int) time.Duration
+}
+
+// FeedHealth описывает результаты последних загрузок ленты
+type FeedHealth struct {
+	// ConsecutiveFailures - число неудачных загрузок подряд
+	ConsecutiveFailures int
+	// LastHTTPStatus - HTTP-статус последнего ответа; 0, если сервер ленты не ответил
+	LastHTTPStatus int
+	// LastError - ошибка последней загрузки; пустая, если загрузка успешна
+	LastError string
+	// LastParseError - ошибка разбора, если последняя загрузка получила не ленту
+	LastParseError string
+	LastFetchedAt  *time.Time
+	LastSuccessAt  *time.Time
+	// NextFetchAt - время следующего фонового обновления на сервере
+	NextFetchAt *time.Time
+	// DisabledAt - время, когда сервер отключил ленту после серии неудач
+	DisabledAt *time.Time
+}
+
+// Status возвращает состояние ленты: disabled, если она отключена, failing
+// после неудачной загрузки, ok после успешной и pending, пока загрузок не было
+func (h FeedHealth) Status() string {
+	switch {
+	case h.DisabledAt != nil:
+		return FeedStatusDisabled
+	case h.LastError != "":
+		return FeedStatusFailing
+	case h.LastFetchedAt != nil:
+		return FeedStatusOK
+	default:
+		return FeedStatusPending
+	}
+}
+
+// RecordFetch учитывает загрузку ленты в момент at, завершившуюся ошибкой err.
+// Успешная загрузка сбрасывает счетчик неудач и снова включает ленту
+func (h *FeedHealth) RecordFetch(at time.Time, err error) {
+	h.LastFetchedAt = &at
+	h.LastHTTPStatus = 0
+	h.LastError, h.LastParseError = "", ""
+
+	var (
+		httpErr  *HTTPError
+		parseErr *ParseError
+	)
+	switch {
+	case errors.As(err, &httpErr):
+		h.LastHTTPStatus = httpErr.StatusCode
+	case errors.As(err, &parseErr):
+		h.LastHTTPStatus = http.StatusOK
+		h.LastParseError = parseErr.Error()
+	case err == nil:
+		h.LastHTTPStatus = http.StatusOK
+	}
+
+	if err == nil {
+		h.ConsecutiveFailures = 0
+		h.LastSuccessAt = &at
+		h.DisabledAt = nil
+		return
+	}
+	h.ConsecutiveFailures++
+	h.LastError = err.Error()
+}
+
+// ScheduleNext назначает следующую загрузку ленты, загруженной в момент at.
+// Паузу после неудач выбирает policy.Backoff. Издатель может попросить паузу
+// requested (Cache-Control: max-age или Retry-After) длиннее, но не короче.
+// Лента, загрузка которой не удалась MaxFailures раз подряд, отключается
+func (h *FeedHealth) ScheduleNext(at time.Time, policy FetchPolicy, requested time.Duration) {
+	if policy.MaxFailures > 0 && h.ConsecutiveFailures >= policy.MaxFailures {
+		h.DisabledAt = &at
+	}
+
+	limit := max(policy.MaxDelay, policy.Interval)
+	delay := policy.Interval
+	if h.ConsecutiveFailures > 0 && policy.Backoff != nil {
+		delay = policy.Backoff(policy.Interval, limit, h.ConsecutiveFailures)
+	}
+
+	next := at.Add(min(max(delay, requested), limit))
+	h.NextFetchAt = &next
+}
@@ -1,17 +1,92 @@
 package entity
 
+import (
+	"context"
+	"time"
+)
+
 // FeedRepository определяет интерфейс для работы с RSS-лентами
 type FeedRepository interface {
-	Create(feed *Feed) error
-	GetByURL(url string) (*Feed, error)
-	GetAll() ([]*Feed, error)
-	GetByID(id int) (*Feed, error)
+	Create(ctx context.Context, feed *Feed) error
+	GetByURL(ctx context.Context, url string) (*Feed, error)
+	GetAll(ctx context.Context) ([]*Feed, error)
+	GetByID(ctx context.Context, id int) (*Feed, error)
+	// UpdateFetched сохраняет валидаторы кэша и формат ленты, полученные при ее загрузке
+	UpdateFetched(ctx context.Context, feed *Feed) error
+	// UpdateHealth сохраняет результаты загрузок ленты
+	UpdateHealth(ctx context.Context, feedID int, health FeedHealth) error
 }
 
 // ArticleRepository определяет интерфейс для работы со статьями
 type ArticleRepository interface {
-	Create(article *Article) error
-	GetByFeedID(feedID int) ([]*Article, error)
-	GetAll() ([]*Article, error)
-	MarkAsRead(articleID int) error
+	Create(ctx context.Context, article *Article) error
+	// Upsert создает статью или обновляет статью ленты с тем же Key.
+	// Возвращает true, если статья была создана
+	Upsert(ctx context.Context, article *Article) (bool, error)
+	// UpsertAll сохраняет статьи так же, как Upsert. Статья, которую не удалось
+	// сохранить, пропускается и попадает в UpsertResult.Errors; ошибка
+	// возвращается, только если сохранение не удалось целиком
+	UpsertAll(ctx context.Context, articles []*Article) (UpsertResult, error)
+	GetByFeedID(ctx context.Context, feedID int) ([]*Article, error)
+	GetAll(ctx context.Context) ([]*Article, error)
+	// Search ищет статьи, в заголовке или тексте которых встречаются все
+	// слова запроса, начиная с наиболее релевантных. Если feedID > 0,
+	// поиск ограничивается этой лентой
+	Search(ctx context.Context, query string, feedID int) ([]*Article, error)
+	MarkAsRead(ctx context.Context, articleID int) error
+}
+
+// SubscriptionRepository определяет постраничный просмотр лент пользователя и
+// отписку от них
+type SubscriptionRepository interface {
+	// GetPage получает страницу лент, упорядоченных по ID, и общее число лент
+	GetPage(ctx context.Context, limit, offset int) ([]*Feed, int, error)
+	// Unsubscribe отписывает от ленты. Возвращает false, если подписки не было
+	Unsubscribe(ctx context.Context, feedID int) (bool, error)
+}
+
+// ReadingRepository определяет выборку статей пользователя вместе с его
+// отметками прочтения и избранного
+type ReadingRepository interface {
+	// GetRecent получает не больше limit последних статей ленты
+	GetRecent(ctx context.Context, feedID, limit int) ([]*Article, error)
+	// List получает страницу статей по фильтру, начиная с новых. Курсор
+	// следующей страницы равен nil, если статей больше нет
+	List(ctx context.Context, filter ArticleFilter) ([]*Article, *ArticleCursor, error)
+	// SearchPage получает страницу результатов поиска, начиная с наиболее
+	// релевантных, и общее число найденных статей
+	SearchPage(ctx context.Context, filter SearchFilter) ([]SearchResult, int, error)
+	// SetState меняет отметки статьи; nil оставляет отметку как есть.
+	// Возвращает nil, если статьи нет
+	SetState(ctx context.Context, articleID int, isRead, isStarred *bool) (*Article, error)
+	// MarkFeedRead помечает статьи ленты прочитанными и возвращает число измененных статей
+	MarkFeedRead(ctx context.Context, feedID int) (int, error)
+	// MarkCategoryRead помечает прочитанными статьи лент категории
+	MarkCategoryRead(ctx context.Context, categoryID int) (int, error)
+	// MarkReadBefore помечает прочитанными статьи, опубликованные раньше before
+	MarkReadBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// CategoryRepository определяет интерфейс для работы с категориями лент
+type CategoryRepository interface {
+	Create(ctx context.Context, category *Category) error
+	GetByID(ctx context.Context, id int) (*Category, error)
+	GetByName(ctx context.Context, name string) (*Category, error)
+	GetAll(ctx context.Context) ([]*Category, error)
+	Update(ctx context.Context, category *Category) error
+	// Delete удаляет категорию; ленты категории остаются
+	Delete(ctx context.Context, id int) error
+	// AssignFeed добавляет ленту в категорию; повторное добавление не является ошибкой
+	AssignFeed(ctx context.Context, feedID, categoryID int) error
+	UnassignFeed(ctx context.Context, feedID, categoryID int) error
+	GetByFeedID(ctx context.Context, feedID int) ([]*Category, error)
+	GetFeedIDs(ctx context.Context, categoryID int) ([]int, error)
+}
+
+// CategorySummaryRepository может реализовать репозиторий категорий, который
+// считает непрочитанные статьи сам, не загружая их
+type CategorySummaryRepository interface {
+	GetSummaries(ctx context.Context) ([]CategorySummary, error)
+	// GetSummary возвращает nil, если категории нет
+	GetSummary(ctx context.Context, id int) (*CategorySummary, error)
 }
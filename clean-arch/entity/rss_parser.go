@@ -1,22 +1,107 @@
 package entity
 
-import "time"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFeed сообщает, что по URL находится не лента, а, например, HTML-страница
+var ErrNotFeed = errors.New("document is not a feed")
+
+// HTTPError сообщает, что сервер ленты ответил статусом, отличным от 2xx и 304
+type HTTPError struct {
+	StatusCode int
+	// RetryAfter - пауза, о которой сервер просит заголовком Retry-After; 0, если ее нет
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *HTTPError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// ParseError сообщает, что сервер ленты ответил, но документ не удалось
+// разобрать как ленту, например из-за некорректного XML
+type ParseError struct {
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
 
 // RSSParser определяет интерфейс для парсинга RSS-лент
 type RSSParser interface {
-	ParseFeed(url string) (*ParsedFeed, error)
+	// ParseFeed загружает и парсит ленту. Если по URL находится не лента,
+	// ошибка соответствует ErrNotFeed; неожиданный HTTP-статус дает *HTTPError,
+	// а документ, который не удалось разобрать, - *ParseError
+	ParseFeed(ctx context.Context, url string) (*ParsedFeed, error)
+	// ParseFeedIfModified загружает ленту так же, как ParseFeed, но условным
+	// запросом с валидаторами кэша feed. Если лента не изменилась, возвращает
+	// ParsedFeed с NotModified, новыми валидаторами и без статей
+	ParseFeedIfModified(ctx context.Context, feed *Feed) (*ParsedFeed, error)
+	// DiscoverFeeds ищет ленты страницы, начиная с лучшей. Пустой список
+	// означает, что лент не найдено
+	DiscoverFeeds(ctx context.Context, url string) ([]FeedCandidate, error)
+}
+
+// FeedCandidate представляет ленту, найденную на странице
+type FeedCandidate struct {
+	URL   string
+	Title string
+	// Type - MIME-тип ленты: application/rss+xml, application/atom+xml или application/feed+json
+	Type string
 }
 
 // ParsedFeed представляет распарсенную RSS-ленту
 type ParsedFeed struct {
 	Title       string
 	Description string
-	Items       []ParsedItem
+	// ETag и LastModified - валидаторы HTTP-кэша из ответа сервера ленты
+	ETag         string
+	LastModified string
+	// Format - формат источника: rss, atom или json (JSON Feed), FormatVersion - его версия
+	Format        string
+	FormatVersion string
+	// NotModified сообщает, что сервер ответил на условный запрос 304 Not Modified
+	NotModified bool
+	// MaxAge - срок свежести ленты из Cache-Control; 0, если сервер его не указал
+	MaxAge time.Duration
+	Items  []ParsedItem
 }
 
 // ParsedItem представляет распарсенную статью из RSS
 type ParsedItem struct {
-	Title           string
-	Content         string
+	// Key - стабильный идентификатор статьи внутри ленты:
+	// GUID, а если его нет - хэш ссылки или содержимого
+	Key   string
+	GUID  string
+	Link  string
+	Title string
+	// Content - очищенный HTML или, для ContentType text, простой текст
+	Content string
+	// RawContent - текст статьи до очистки
+	RawContent string
+	// ContentType - разметка Content: html, xhtml или text
+	ContentType     string
+	Summary         string
 	PublicationDate *time.Time
+	PublishedAt     *time.Time
+	UpdatedAt       *time.Time
+	Authors         []string
+	Categories      []string
+	Enclosures      []Enclosure
+	ImageURL        string
+	BannerImage     string
+	// Extensions - расширения JSON Feed в виде объекта JSON
+	Extensions string
 }
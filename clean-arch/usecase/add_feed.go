@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"rss-aggregator/clean-arch/entity"
@@ -22,47 +24,98 @@ func NewAddFeedUseCase(feedRepo entity.FeedRepository, articleRepo entity.Articl
 	}
 }
 
-// Execute выполняет добавление RSS-ленты
-func (uc *AddFeedUseCase) Execute(url string) (*entity.Feed, error) {
-	// Проверяем, не существует ли уже лента с таким URL
-	existingFeed, err := uc.feedRepo.GetByURL(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check feed existence: %w", err)
-	}
-	if existingFeed != nil {
-		return nil, fmt.Errorf("feed with URL %s already exists", url)
+// Execute выполняет добавление RSS-ленты. Если по URL находится страница сайта,
+// добавляется лучшая из найденных на ней лент. Если лента уже добавлена, возвращает
+// ErrFeedExists, а если ее не удалось загрузить - *FetchError. Статьи, которые
+// не удалось сохранить, не прерывают добавление и перечисляются в результате
+func (uc *AddFeedUseCase) Execute(ctx context.Context, url string) (*entity.Feed, entity.UpsertResult, error) {
+	if err := uc.checkNotExists(ctx, url); err != nil {
+		return nil, entity.UpsertResult{}, err
 	}
 
 	// Парсим RSS-ленту
-	parsedFeed, err := uc.parser.ParseFeed(url)
+	parsedFeed, err := uc.parser.ParseFeed(ctx, url)
+	if errors.Is(err, entity.ErrNotFeed) {
+		// Вместо ленты пришла страница: ищем на ней ленты и берем лучшую
+		candidates, discoverErr := uc.parser.DiscoverFeeds(ctx, url)
+		if discoverErr == nil && len(candidates) > 0 {
+			url = candidates[0].URL
+			if err := uc.checkNotExists(ctx, url); err != nil {
+				return nil, entity.UpsertResult{}, err
+			}
+			parsedFeed, err = uc.parser.ParseFeed(ctx, url)
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+		return nil, entity.UpsertResult{}, &FetchError{URL: url, Err: err}
 	}
 
 	// Создаем ленту
 	feed := &entity.Feed{
-		URL:         url,
-		Title:       parsedFeed.Title,
-		Description: parsedFeed.Description,
+		URL:           url,
+		Title:         parsedFeed.Title,
+		Description:   parsedFeed.Description,
+		ETag:          parsedFeed.ETag,
+		LastModified:  parsedFeed.LastModified,
+		Format:        parsedFeed.Format,
+		FormatVersion: parsedFeed.FormatVersion,
 	}
 
-	if err := uc.feedRepo.Create(feed); err != nil {
-		return nil, fmt.Errorf("failed to create feed: %w", err)
+	if err := uc.feedRepo.Create(ctx, feed); err != nil {
+		return nil, entity.UpsertResult{}, fmt.Errorf("failed to create feed: %w", err)
 	}
 
 	// Сохраняем статьи из ленты
-	for _, item := range parsedFeed.Items {
-		article := &entity.Article{
-			FeedID:          feed.ID,
-			Title:           item.Title,
-			Content:         item.Content,
-			PublicationDate: item.PublicationDate,
-			IsRead:          false,
-		}
-		if err := uc.articleRepo.Create(article); err != nil {
-			return nil, fmt.Errorf("failed to create article: %w", err)
-		}
+	result, err := uc.articleRepo.UpsertAll(ctx, newArticles(feed.ID, parsedFeed.Items))
+	if err != nil {
+		return nil, result, fmt.Errorf("failed to save articles: %w", err)
+	}
+
+	return feed, result, nil
+}
+
+// checkNotExists возвращает ErrFeedExists, если лента с таким URL уже добавлена
+func (uc *AddFeedUseCase) checkNotExists(ctx context.Context, url string) error {
+	existingFeed, err := uc.feedRepo.GetByURL(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to check feed existence: %w", err)
+	}
+	if existingFeed != nil {
+		return fmt.Errorf("feed with URL %s: %w", url, ErrFeedExists)
 	}
+	return nil
+}
 
-	return feed, nil
+// newArticles создает статьи ленты из распарсенных элементов
+func newArticles(feedID int, items []entity.ParsedItem) []*entity.Article {
+	articles := make([]*entity.Article, 0, len(items))
+	for _, item := range items {
+		articles = append(articles, newArticle(feedID, item))
+	}
+	return articles
+}
+
+// newArticle создает статью ленты из распарсенного элемента
+func newArticle(feedID int, item entity.ParsedItem) *entity.Article {
+	return &entity.Article{
+		FeedID:          feedID,
+		Key:             item.Key,
+		GUID:            item.GUID,
+		Link:            item.Link,
+		Title:           item.Title,
+		Content:         item.Content,
+		RawContent:      item.RawContent,
+		ContentType:     item.ContentType,
+		Summary:         item.Summary,
+		PublicationDate: item.PublicationDate,
+		PublishedAt:     item.PublishedAt,
+		UpdatedAt:       item.UpdatedAt,
+		Authors:         item.Authors,
+		Categories:      item.Categories,
+		Enclosures:      item.Enclosures,
+		ImageURL:        item.ImageURL,
+		BannerImage:     item.BannerImage,
+		Extensions:      item.Extensions,
+		IsRead:          false,
+	}
 }
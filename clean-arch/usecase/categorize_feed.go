@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// CategorizeFeedUseCase представляет use case для добавления ленты в категорию и удаления из нее
+type CategorizeFeedUseCase struct {
+	feedRepo     entity.FeedRepository
+	categoryRepo entity.CategoryRepository
+}
+
+// NewCategorizeFeedUseCase создает новый экземпляр CategorizeFeedUseCase
+func NewCategorizeFeedUseCase(feedRepo entity.FeedRepository, categoryRepo entity.CategoryRepository) *CategorizeFeedUseCase {
+	return &CategorizeFeedUseCase{
+		feedRepo:     feedRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// Assign добавляет ленту в категорию с указанным названием, создавая категорию при необходимости
+func (uc *CategorizeFeedUseCase) Assign(ctx context.Context, feedID int, name string) (*entity.Category, error) {
+	feed, err := uc.feedRepo.GetByID(ctx, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+	if feed == nil {
+		return nil, fmt.Errorf("feed with ID %d not found", feedID)
+	}
+
+	category, err := uc.categoryRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		category = &entity.Category{Name: name}
+		if err := uc.categoryRepo.Create(ctx, category); err != nil {
+			return nil, fmt.Errorf("failed to create category: %w", err)
+		}
+	}
+
+	if err := uc.categoryRepo.AssignFeed(ctx, feed.ID, category.ID); err != nil {
+		return nil, fmt.Errorf("failed to assign category: %w", err)
+	}
+
+	return category, nil
+}
+
+// Unassign убирает ленту из категории с указанным названием
+func (uc *CategorizeFeedUseCase) Unassign(ctx context.Context, feedID int, name string) error {
+	category, err := uc.categoryRepo.GetByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		return fmt.Errorf("category %s not found", name)
+	}
+
+	return uc.categoryRepo.UnassignFeed(ctx, feedID, category.ID)
+}
+
+// AssignByID добавляет ленту в существующую категорию. Возвращает
+// ErrCategoryNotFound или ErrFeedNotFound, если категории или ленты нет
+func (uc *CategorizeFeedUseCase) AssignByID(ctx context.Context, feedID, categoryID int) error {
+	category, err := uc.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		return ErrCategoryNotFound
+	}
+
+	feed, err := uc.feedRepo.GetByID(ctx, feedID)
+	if err != nil {
+		return fmt.Errorf("failed to get feed: %w", err)
+	}
+	if feed == nil {
+		return ErrFeedNotFound
+	}
+
+	if err := uc.categoryRepo.AssignFeed(ctx, feed.ID, category.ID); err != nil {
+		return fmt.Errorf("failed to assign category: %w", err)
+	}
+
+	return nil
+}
+
+// UnassignByID убирает ленту из категории. Возвращает ErrCategoryNotFound,
+// если категории нет, и ErrFeedNotInCategory, если ленты в ней нет
+func (uc *CategorizeFeedUseCase) UnassignByID(ctx context.Context, feedID, categoryID int) error {
+	category, err := uc.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		return ErrCategoryNotFound
+	}
+
+	feedIDs, err := uc.categoryRepo.GetFeedIDs(ctx, category.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get category feeds: %w", err)
+	}
+	if !slices.Contains(feedIDs, feedID) {
+		return ErrFeedNotInCategory
+	}
+
+	if err := uc.categoryRepo.UnassignFeed(ctx, feedID, category.ID); err != nil {
+		return fmt.Errorf("failed to unassign category: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// DiscoverFeedsUseCase представляет use case для поиска лент на странице сайта
+type DiscoverFeedsUseCase struct {
+	parser entity.RSSParser
+}
+
+// NewDiscoverFeedsUseCase создает новый экземпляр DiscoverFeedsUseCase
+func NewDiscoverFeedsUseCase(parser entity.RSSParser) *DiscoverFeedsUseCase {
+	return &DiscoverFeedsUseCase{
+		parser: parser,
+	}
+}
+
+// Execute возвращает ленты страницы, начиная с лучшей, которую выбирает
+// AddFeedUseCase. Если страницу не удалось загрузить, возвращает *FetchError
+func (uc *DiscoverFeedsUseCase) Execute(ctx context.Context, url string) ([]entity.FeedCandidate, error) {
+	candidates, err := uc.parser.DiscoverFeeds(ctx, url)
+	if err != nil {
+		return nil, &FetchError{URL: url, Err: err}
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrFeedExists возвращается при добавлении ленты, которая уже добавлена
+	ErrFeedExists = errors.New("feed already exists")
+	// ErrFeedNotFound возвращается, если ленты нет или пользователь на нее не подписан
+	ErrFeedNotFound = errors.New("feed not found")
+	// ErrArticleNotFound возвращается, если статьи нет среди статей пользователя
+	ErrArticleNotFound = errors.New("article not found")
+	// ErrCategoryNotFound возвращается, если у пользователя нет такой категории
+	ErrCategoryNotFound = errors.New("category not found")
+	// ErrCategoryExists возвращается, если категория с таким названием уже есть
+	ErrCategoryExists = errors.New("category already exists")
+	// ErrFeedNotInCategory возвращается при удалении из категории ленты, которой в ней нет
+	ErrFeedNotInCategory = errors.New("feed is not in category")
+)
+
+// FetchError сообщает, что ленту не удалось загрузить или распарсить
+type FetchError struct {
+	URL string
+	Err error
+}
+
+func (e *FetchError) Error() string {
+	return fmt.Sprintf("failed to fetch %s: %v", e.URL, e.Err)
+}
+
+func (e *FetchError) Unwrap() error { return e.Err }
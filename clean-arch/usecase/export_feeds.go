@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// ExportFeedsUseCase представляет use case для экспорта списка подписок
+type ExportFeedsUseCase struct {
+	feedRepo     entity.FeedRepository
+	categoryRepo entity.CategoryRepository
+}
+
+// NewExportFeedsUseCase создает новый экземпляр ExportFeedsUseCase
+func NewExportFeedsUseCase(feedRepo entity.FeedRepository, categoryRepo entity.CategoryRepository) *ExportFeedsUseCase {
+	return &ExportFeedsUseCase{
+		feedRepo:     feedRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// Execute возвращает подписки на все ленты вместе с их категориями
+func (uc *ExportFeedsUseCase) Execute(ctx context.Context) ([]entity.Subscription, error) {
+	feeds, err := uc.feedRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feeds: %w", err)
+	}
+	sort.Slice(feeds, func(i, j int) bool {
+		return feeds[i].ID < feeds[j].ID
+	})
+
+	subscriptions := make([]entity.Subscription, 0, len(feeds))
+	for _, feed := range feeds {
+		categories, err := uc.categoryRepo.GetByFeedID(ctx, feed.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get feed categories: %w", err)
+		}
+
+		subscription := entity.Subscription{
+			URL:   feed.URL,
+			Title: feed.Title,
+		}
+		for _, category := range categories {
+			subscription.Categories = append(subscription.Categories, category.Name)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
@@ -1,7 +1,10 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"rss-aggregator/clean-arch/entity"
 )
@@ -22,51 +25,51 @@ func NewFetchArticlesUseCase(feedRepo entity.FeedRepository, articleRepo entity.
 	}
 }
 
-// Execute выполняет обновление статей из RSS-ленты
-func (uc *FetchArticlesUseCase) Execute(feedID int) error {
+// Execute выполняет обновление статей из RSS-ленты. Загрузка условная: если
+// лента не изменилась с прошлой загрузки, возвращается ParsedFeed с NotModified,
+// а статьи не сохраняются. Новые статьи создаются, измененные обновляются по
+// ключу; статьи, которые не удалось сохранить, пропускаются и перечисляются в
+// результате. Результат загрузки ленты сохраняется в ее FeedHealth
+func (uc *FetchArticlesUseCase) Execute(ctx context.Context, feedID int) (*entity.ParsedFeed, entity.UpsertResult, error) {
 	// Получаем ленту
-	feed, err := uc.feedRepo.GetByID(feedID)
+	feed, err := uc.feedRepo.GetByID(ctx, feedID)
 	if err != nil {
-		return fmt.Errorf("failed to get feed: %w", err)
+		return nil, entity.UpsertResult{}, fmt.Errorf("failed to get feed: %w", err)
 	}
 	if feed == nil {
-		return fmt.Errorf("feed with ID %d not found", feedID)
+		return nil, entity.UpsertResult{}, fmt.Errorf("feed with ID %d not found", feedID)
 	}
 
 	// Парсим RSS-ленту
-	parsedFeed, err := uc.parser.ParseFeed(feed.URL)
-	if err != nil {
-		return fmt.Errorf("failed to parse RSS feed: %w", err)
+	parsedFeed, err := uc.parser.ParseFeedIfModified(ctx, feed)
+	feed.Health.RecordFetch(time.Now().UTC(), err)
+	if err == nil && parsedFeed.NotModified {
+		feed.Health.LastHTTPStatus = http.StatusNotModified
+	}
+	if healthErr := uc.feedRepo.UpdateHealth(ctx, feedID, feed.Health); healthErr != nil {
+		return nil, entity.UpsertResult{}, fmt.Errorf("failed to save feed health: %w", healthErr)
 	}
-
-	// Получаем существующие статьи
-	existingArticles, err := uc.articleRepo.GetByFeedID(feedID)
 	if err != nil {
-		return fmt.Errorf("failed to get existing articles: %w", err)
+		return nil, entity.UpsertResult{}, &FetchError{URL: feed.URL, Err: err}
 	}
 
-	// Создаем map для быстрой проверки существующих статей
-	existingTitles := make(map[string]bool)
-	for _, article := range existingArticles {
-		existingTitles[article.Title] = true
+	// Сохраняем валидаторы для следующей загрузки; ответ 304 не сообщает формат
+	feed.ETag, feed.LastModified = parsedFeed.ETag, parsedFeed.LastModified
+	if !parsedFeed.NotModified {
+		feed.Format, feed.FormatVersion = parsedFeed.Format, parsedFeed.FormatVersion
+	}
+	if err := uc.feedRepo.UpdateFetched(ctx, feed); err != nil {
+		return nil, entity.UpsertResult{}, fmt.Errorf("failed to save feed: %w", err)
+	}
+	if parsedFeed.NotModified {
+		return parsedFeed, entity.UpsertResult{}, nil
 	}
 
-	// Добавляем новые статьи
-	for _, item := range parsedFeed.Items {
-		if !existingTitles[item.Title] {
-			article := &entity.Article{
-				FeedID:         feedID,
-				Title:          item.Title,
-				Content:        item.Content,
-				PublicationDate: item.PublicationDate,
-				IsRead:         false,
-			}
-			if err := uc.articleRepo.Create(article); err != nil {
-				return fmt.Errorf("failed to create article: %w", err)
-			}
-		}
+	// Сохраняем статьи
+	result, err := uc.articleRepo.UpsertAll(ctx, newArticles(feedID, parsedFeed.Items))
+	if err != nil {
+		return nil, result, fmt.Errorf("failed to save articles: %w", err)
 	}
 
-	return nil
+	return parsedFeed, result, nil
 }
-
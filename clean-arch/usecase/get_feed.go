@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// GetFeedUseCase представляет use case для получения ленты с ее последними статьями
+type GetFeedUseCase struct {
+	feedRepo    entity.FeedRepository
+	readingRepo entity.ReadingRepository
+}
+
+// NewGetFeedUseCase создает новый экземпляр GetFeedUseCase
+func NewGetFeedUseCase(feedRepo entity.FeedRepository, readingRepo entity.ReadingRepository) *GetFeedUseCase {
+	return &GetFeedUseCase{
+		feedRepo:    feedRepo,
+		readingRepo: readingRepo,
+	}
+}
+
+// Execute возвращает ленту и не больше articlesLimit ее последних статей.
+// Если ленты нет, возвращает ErrFeedNotFound
+func (uc *GetFeedUseCase) Execute(ctx context.Context, feedID, articlesLimit int) (*entity.Feed, []*entity.Article, error) {
+	feed, err := uc.feedRepo.GetByID(ctx, feedID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+	if feed == nil {
+		return nil, nil, ErrFeedNotFound
+	}
+
+	articles, err := uc.readingRepo.GetRecent(ctx, feed.ID, articlesLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get articles: %w", err)
+	}
+
+	return feed, articles, nil
+}
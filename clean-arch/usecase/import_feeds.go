@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// importWorkers ограничивает число лент, загружаемых одновременно при импорте
+const importWorkers = 4
+
+// ImportFeedsUseCase представляет use case для импорта списка подписок
+type ImportFeedsUseCase struct {
+	feedRepo     entity.FeedRepository
+	categoryRepo entity.CategoryRepository
+	addFeed      *AddFeedUseCase
+
+	// categoryMu защищает поиск и создание категорий параллельными загрузками
+	categoryMu sync.Mutex
+}
+
+// NewImportFeedsUseCase создает новый экземпляр ImportFeedsUseCase
+func NewImportFeedsUseCase(feedRepo entity.FeedRepository, articleRepo entity.ArticleRepository, categoryRepo entity.CategoryRepository, parser entity.RSSParser) *ImportFeedsUseCase {
+	return &ImportFeedsUseCase{
+		feedRepo:     feedRepo,
+		categoryRepo: categoryRepo,
+		addFeed:      NewAddFeedUseCase(feedRepo, articleRepo, parser),
+	}
+}
+
+// Execute выполняет импорт подписок. Ленты загружаются параллельно,
+// результаты возвращаются в порядке подписок
+func (uc *ImportFeedsUseCase) Execute(ctx context.Context, subscriptions []entity.Subscription) []entity.ImportResult {
+	results := make([]entity.ImportResult, len(subscriptions))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(importWorkers, len(subscriptions)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = uc.importSubscription(ctx, subscriptions[i])
+			}
+		}()
+	}
+	for i := range subscriptions {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// importSubscription добавляет ленту, если ее еще нет, и помещает ее в категории подписки
+func (uc *ImportFeedsUseCase) importSubscription(ctx context.Context, subscription entity.Subscription) entity.ImportResult {
+	result := entity.ImportResult{
+		URL:    subscription.URL,
+		Status: entity.ImportStatusExists,
+	}
+
+	feed, err := uc.feedRepo.GetByURL(ctx, subscription.URL)
+	if err != nil {
+		result.Status, result.Err = entity.ImportStatusFailed, fmt.Errorf("failed to check feed existence: %w", err)
+		return result
+	}
+	if feed == nil {
+		feed, _, err = uc.addFeed.Execute(ctx, subscription.URL)
+		if err != nil {
+			result.Status, result.Err = entity.ImportStatusFailed, err
+			return result
+		}
+		result.Status = entity.ImportStatusAdded
+	}
+	result.Feed = feed
+
+	for _, name := range subscription.Categories {
+		category, err := uc.category(ctx, name)
+		if err != nil {
+			result.Status, result.Err = entity.ImportStatusFailed, err
+			return result
+		}
+		if err := uc.categoryRepo.AssignFeed(ctx, feed.ID, category.ID); err != nil {
+			result.Status, result.Err = entity.ImportStatusFailed, fmt.Errorf("failed to assign category: %w", err)
+			return result
+		}
+	}
+
+	return result
+}
+
+// category находит категорию по названию или создает ее
+func (uc *ImportFeedsUseCase) category(ctx context.Context, name string) (*entity.Category, error) {
+	uc.categoryMu.Lock()
+	defer uc.categoryMu.Unlock()
+
+	category, err := uc.categoryRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if category != nil {
+		return category, nil
+	}
+
+	category = &entity.Category{Name: name}
+	if err := uc.categoryRepo.Create(ctx, category); err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return category, nil
+}
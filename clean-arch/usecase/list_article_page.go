@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// ListArticlePageUseCase представляет use case для постраничного получения статей пользователя
+type ListArticlePageUseCase struct {
+	readingRepo entity.ReadingRepository
+}
+
+// NewListArticlePageUseCase создает новый экземпляр ListArticlePageUseCase
+func NewListArticlePageUseCase(readingRepo entity.ReadingRepository) *ListArticlePageUseCase {
+	return &ListArticlePageUseCase{
+		readingRepo: readingRepo,
+	}
+}
+
+// Execute возвращает страницу статей по фильтру, начиная с новых, и курсор
+// следующей страницы или nil, если статей больше нет
+func (uc *ListArticlePageUseCase) Execute(ctx context.Context, filter entity.ArticleFilter) ([]*entity.Article, *entity.ArticleCursor, error) {
+	return uc.readingRepo.List(ctx, filter)
+}
@@ -1,6 +1,10 @@
 package usecase
 
-import "rss-aggregator/clean-arch/entity"
+import (
+	"context"
+
+	"rss-aggregator/clean-arch/entity"
+)
 
 // ListArticlesUseCase представляет use case для получения списка статей
 type ListArticlesUseCase struct {
@@ -16,10 +20,9 @@ func NewListArticlesUseCase(articleRepo entity.ArticleRepository) *ListArticlesU
 
 // Execute выполняет получение списка статей
 // Если feedID > 0, возвращает статьи только для этой ленты
-func (uc *ListArticlesUseCase) Execute(feedID int) ([]*entity.Article, error) {
+func (uc *ListArticlesUseCase) Execute(ctx context.Context, feedID int) ([]*entity.Article, error) {
 	if feedID > 0 {
-		return uc.articleRepo.GetByFeedID(feedID)
+		return uc.articleRepo.GetByFeedID(ctx, feedID)
 	}
-	return uc.articleRepo.GetAll()
+	return uc.articleRepo.GetAll(ctx)
 }
-
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// ListCategoriesUseCase представляет use case для получения списка категорий
+type ListCategoriesUseCase struct {
+	categoryRepo entity.CategoryRepository
+	articleRepo  entity.ArticleRepository
+}
+
+// NewListCategoriesUseCase создает новый экземпляр ListCategoriesUseCase
+func NewListCategoriesUseCase(categoryRepo entity.CategoryRepository, articleRepo entity.ArticleRepository) *ListCategoriesUseCase {
+	return &ListCategoriesUseCase{
+		categoryRepo: categoryRepo,
+		articleRepo:  articleRepo,
+	}
+}
+
+// Execute возвращает все категории с их лентами и числом непрочитанных статей.
+// Если репозиторий категорий реализует CategorySummaryRepository, сводки
+// берутся из него, иначе непрочитанные статьи считаются по статьям лент
+func (uc *ListCategoriesUseCase) Execute(ctx context.Context) ([]entity.CategorySummary, error) {
+	if summaryRepo, ok := uc.categoryRepo.(entity.CategorySummaryRepository); ok {
+		summaries, err := summaryRepo.GetSummaries(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get categories: %w", err)
+		}
+		return summaries, nil
+	}
+
+	categories, err := uc.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	summaries := make([]entity.CategorySummary, 0, len(categories))
+	for _, category := range categories {
+		summary, err := uc.summarize(ctx, category)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, *summary)
+	}
+
+	return summaries, nil
+}
+
+// Get возвращает категорию с ее лентами и числом непрочитанных статей так же,
+// как Execute. Если категории нет, возвращает ErrCategoryNotFound
+func (uc *ListCategoriesUseCase) Get(ctx context.Context, id int) (*entity.CategorySummary, error) {
+	if summaryRepo, ok := uc.categoryRepo.(entity.CategorySummaryRepository); ok {
+		summary, err := summaryRepo.GetSummary(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get category: %w", err)
+		}
+		if summary == nil {
+			return nil, ErrCategoryNotFound
+		}
+		return summary, nil
+	}
+
+	category, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	return uc.summarize(ctx, category)
+}
+
+// summarize считает непрочитанные статьи лент категории
+func (uc *ListCategoriesUseCase) summarize(ctx context.Context, category *entity.Category) (*entity.CategorySummary, error) {
+	feedIDs, err := uc.categoryRepo.GetFeedIDs(ctx, category.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category feeds: %w", err)
+	}
+
+	summary := &entity.CategorySummary{Category: *category, FeedIDs: feedIDs}
+	for _, feedID := range feedIDs {
+		articles, err := uc.articleRepo.GetByFeedID(ctx, feedID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get articles: %w", err)
+		}
+		for _, article := range articles {
+			if !article.IsRead {
+				summary.UnreadCount++
+			}
+		}
+	}
+
+	return summary, nil
+}
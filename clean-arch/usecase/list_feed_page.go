@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// ListFeedPageUseCase представляет use case для постраничного получения лент пользователя
+type ListFeedPageUseCase struct {
+	subscriptionRepo entity.SubscriptionRepository
+}
+
+// NewListFeedPageUseCase создает новый экземпляр ListFeedPageUseCase
+func NewListFeedPageUseCase(subscriptionRepo entity.SubscriptionRepository) *ListFeedPageUseCase {
+	return &ListFeedPageUseCase{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// Execute возвращает страницу лент и общее число лент пользователя
+func (uc *ListFeedPageUseCase) Execute(ctx context.Context, limit, offset int) ([]*entity.Feed, int, error) {
+	return uc.subscriptionRepo.GetPage(ctx, limit, offset)
+}
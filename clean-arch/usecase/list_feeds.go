@@ -1,6 +1,10 @@
 package usecase
 
-import "rss-aggregator/clean-arch/entity"
+import (
+	"context"
+
+	"rss-aggregator/clean-arch/entity"
+)
 
 // ListFeedsUseCase представляет use case для получения списка RSS-лент
 type ListFeedsUseCase struct {
@@ -15,7 +19,6 @@ func NewListFeedsUseCase(feedRepo entity.FeedRepository) *ListFeedsUseCase {
 }
 
 // Execute выполняет получение списка всех RSS-лент
-func (uc *ListFeedsUseCase) Execute() ([]*entity.Feed, error) {
-	return uc.feedRepo.GetAll()
+func (uc *ListFeedsUseCase) Execute(ctx context.Context) ([]*entity.Feed, error) {
+	return uc.feedRepo.GetAll(ctx)
 }
-
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// ManageCategoriesUseCase представляет use case для создания, переименования и удаления категорий
+type ManageCategoriesUseCase struct {
+	categoryRepo entity.CategoryRepository
+}
+
+// NewManageCategoriesUseCase создает новый экземпляр ManageCategoriesUseCase
+func NewManageCategoriesUseCase(categoryRepo entity.CategoryRepository) *ManageCategoriesUseCase {
+	return &ManageCategoriesUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// Create создает категорию. Если категория с таким названием уже есть,
+// возвращает ErrCategoryExists
+func (uc *ManageCategoriesUseCase) Create(ctx context.Context, name string) (*entity.Category, error) {
+	existing, err := uc.categoryRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrCategoryExists
+	}
+
+	category := &entity.Category{Name: name}
+	if err := uc.categoryRepo.Create(ctx, category); err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return category, nil
+}
+
+// Rename переименовывает категорию. Возвращает ErrCategoryNotFound, если ее
+// нет, и ErrCategoryExists, если название занято другой категорией
+func (uc *ManageCategoriesUseCase) Rename(ctx context.Context, id int, name string) error {
+	category, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		return ErrCategoryNotFound
+	}
+
+	existing, err := uc.categoryRepo.GetByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	if existing != nil && existing.ID != id {
+		return ErrCategoryExists
+	}
+
+	category.Name = name
+	if err := uc.categoryRepo.Update(ctx, category); err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет категорию; ее ленты остаются. Если категории нет,
+// возвращает ErrCategoryNotFound
+func (uc *ManageCategoriesUseCase) Delete(ctx context.Context, id int) error {
+	category, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		return ErrCategoryNotFound
+	}
+
+	if err := uc.categoryRepo.Delete(ctx, category.ID); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// MarkArticlesUseCase представляет use case для отметок прочтения и избранного
+type MarkArticlesUseCase struct {
+	feedRepo     entity.FeedRepository
+	categoryRepo entity.CategoryRepository
+	readingRepo  entity.ReadingRepository
+}
+
+// NewMarkArticlesUseCase создает новый экземпляр MarkArticlesUseCase
+func NewMarkArticlesUseCase(feedRepo entity.FeedRepository, categoryRepo entity.CategoryRepository, readingRepo entity.ReadingRepository) *MarkArticlesUseCase {
+	return &MarkArticlesUseCase{
+		feedRepo:     feedRepo,
+		categoryRepo: categoryRepo,
+		readingRepo:  readingRepo,
+	}
+}
+
+// SetState меняет отметки статьи; nil оставляет отметку как есть.
+// Если статьи нет, возвращает ErrArticleNotFound
+func (uc *MarkArticlesUseCase) SetState(ctx context.Context, articleID int, isRead, isStarred *bool) (*entity.Article, error) {
+	article, err := uc.readingRepo.SetState(ctx, articleID, isRead, isStarred)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update article: %w", err)
+	}
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+
+	return article, nil
+}
+
+// MarkFeedRead помечает статьи ленты прочитанными и возвращает число измененных
+// статей. Если ленты нет, возвращает ErrFeedNotFound
+func (uc *MarkArticlesUseCase) MarkFeedRead(ctx context.Context, feedID int) (int, error) {
+	feed, err := uc.feedRepo.GetByID(ctx, feedID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get feed: %w", err)
+	}
+	if feed == nil {
+		return 0, ErrFeedNotFound
+	}
+
+	return uc.readingRepo.MarkFeedRead(ctx, feed.ID)
+}
+
+// MarkCategoryRead помечает прочитанными статьи лент категории и возвращает
+// число измененных статей. Если категории нет, возвращает ErrCategoryNotFound
+func (uc *MarkArticlesUseCase) MarkCategoryRead(ctx context.Context, categoryID int) (int, error) {
+	category, err := uc.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		return 0, ErrCategoryNotFound
+	}
+
+	return uc.readingRepo.MarkCategoryRead(ctx, category.ID)
+}
+
+// MarkReadBefore помечает прочитанными статьи, опубликованные раньше before,
+// и возвращает число измененных статей
+func (uc *MarkArticlesUseCase) MarkReadBefore(ctx context.Context, before time.Time) (int, error) {
+	return uc.readingRepo.MarkReadBefore(ctx, before)
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// SearchArticlePageUseCase представляет use case для постраничного поиска статей
+// пользователя с подсветкой найденных слов
+type SearchArticlePageUseCase struct {
+	readingRepo entity.ReadingRepository
+}
+
+// NewSearchArticlePageUseCase создает новый экземпляр SearchArticlePageUseCase
+func NewSearchArticlePageUseCase(readingRepo entity.ReadingRepository) *SearchArticlePageUseCase {
+	return &SearchArticlePageUseCase{
+		readingRepo: readingRepo,
+	}
+}
+
+// Execute возвращает страницу результатов поиска, начиная с наиболее
+// релевантных, и общее число найденных статей
+func (uc *SearchArticlePageUseCase) Execute(ctx context.Context, filter entity.SearchFilter) ([]entity.SearchResult, int, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Query == "" {
+		return nil, 0, fmt.Errorf("search query is empty")
+	}
+
+	return uc.readingRepo.SearchPage(ctx, filter)
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// SearchArticlesUseCase представляет use case для поиска статей
+type SearchArticlesUseCase struct {
+	articleRepo entity.ArticleRepository
+}
+
+// NewSearchArticlesUseCase создает новый экземпляр SearchArticlesUseCase
+func NewSearchArticlesUseCase(articleRepo entity.ArticleRepository) *SearchArticlesUseCase {
+	return &SearchArticlesUseCase{
+		articleRepo: articleRepo,
+	}
+}
+
+// Execute выполняет поиск статей по запросу
+// Если feedID > 0, ищет только в статьях этой ленты
+func (uc *SearchArticlesUseCase) Execute(ctx context.Context, query string, feedID int) ([]*entity.Article, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("search query is empty")
+	}
+
+	return uc.articleRepo.Search(ctx, query, feedID)
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"rss-aggregator/clean-arch/entity"
+)
+
+// UnsubscribeFeedUseCase представляет use case для отписки от ленты
+type UnsubscribeFeedUseCase struct {
+	subscriptionRepo entity.SubscriptionRepository
+}
+
+// NewUnsubscribeFeedUseCase создает новый экземпляр UnsubscribeFeedUseCase
+func NewUnsubscribeFeedUseCase(subscriptionRepo entity.SubscriptionRepository) *UnsubscribeFeedUseCase {
+	return &UnsubscribeFeedUseCase{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+// Execute отписывает от ленты. Если подписки нет, возвращает ErrFeedNotFound
+func (uc *UnsubscribeFeedUseCase) Execute(ctx context.Context, feedID int) error {
+	unsubscribed, err := uc.subscriptionRepo.Unsubscribe(ctx, feedID)
+	if err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+	if !unsubscribed {
+		return ErrFeedNotFound
+	}
+
+	return nil
+}
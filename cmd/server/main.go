@@ -1,19 +1,28 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
+	httpadapter "rss-aggregator/clean-arch/adapter/http"
 	api "rss-aggregator/gen"
 	"rss-aggregator/internal/database"
-	"rss-aggregator/internal/service"
+	"rss-aggregator/internal/jobs"
+	"rss-aggregator/internal/rss"
+	"rss-aggregator/internal/scheduler"
+	"rss-aggregator/internal/webhooks"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 )
 
 func main() {
-	// Get database path from environment or use default
+	// Get database path or PostgreSQL URL from environment or use default
 	dbPath := os.Getenv("DB_PATH")
 	if dbPath == "" {
 		dbPath = "./rss.db"
@@ -26,8 +35,29 @@ func main() {
 	}
 	defer db.Close()
 
+	// "server migrate <command>" manages the schema instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), db, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	// Bring the schema up to date
+	applied, err := db.Migrate(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to apply migrations: %v", err)
+	}
+	for _, migration := range applied {
+		log.Printf("Applied migration %s", migration)
+	}
+
 	// Create service
-	svc := service.New(db)
+	parser := rss.NewParserWithLimits(
+		envDuration("FETCH_TIMEOUT", rss.DefaultFetchTimeout),
+		int64(envInt("FETCH_MAX_SIZE", rss.DefaultMaxFeedSize)),
+	)
+	svc := httpadapter.New(db, parser)
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
@@ -44,9 +74,56 @@ func main() {
 
 	// Add middleware
 	app.Use(logger.New())
+	app.Use(httpadapter.Timeout(envDuration("REQUEST_TIMEOUT", httpadapter.DefaultRequestTimeout)))
+
+	// Register API handlers behind token authentication
+	api.RegisterHandlersWithOptions(app, svc, api.FiberServerOptions{
+		Middlewares: []api.MiddlewareFunc{svc.Authenticate},
+	})
+
+	// Start background feed refresh and stop it together with the app
+	sched := scheduler.New(db, svc, scheduler.Config{
+		Interval:    envDuration("REFRESH_INTERVAL", scheduler.DefaultInterval),
+		Workers:     envInt("REFRESH_WORKERS", scheduler.DefaultWorkers),
+		MaxFailures: envInt("FEED_MAX_FAILURES", scheduler.DefaultMaxFailures),
+	})
+	sched.Start()
+
+	// Run queued feed jobs, including those interrupted by the last shutdown
+	pool := jobs.New(db, svc, jobs.Config{
+		Workers: envInt("JOB_WORKERS", jobs.DefaultWorkers),
+		Wake:    svc.JobQueued(),
+	})
+	pool.Start()
+
+	// Send the webhook deliveries queued for new articles, retrying failed ones
+	dispatcher := webhooks.New(db, webhooks.Config{
+		Workers:     envInt("WEBHOOK_WORKERS", webhooks.DefaultWorkers),
+		MaxAttempts: envInt("WEBHOOK_MAX_ATTEMPTS", webhooks.DefaultMaxAttempts),
+		RetryDelay:  envDuration("WEBHOOK_RETRY_DELAY", webhooks.DefaultRetryDelay),
+		Wake:        svc.DeliveryQueued(),
+	})
+	dispatcher.Start()
 
-	// Register API handlers
-	api.RegisterHandlers(app, svc)
+	app.Hooks().OnShutdown(func() error {
+		sched.Stop()
+		pool.Stop()
+		dispatcher.Stop()
+		return nil
+	})
+
+	// Shut down gracefully on SIGINT/SIGTERM
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+		log.Println("Shutting down server")
+		// Open streams would hold the shutdown up until their clients leave
+		svc.CloseStreams()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("Failed to shut down server: %v", err)
+		}
+	}()
 
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
@@ -59,3 +136,31 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// envDuration reads a duration such as "15m" from the environment
+func envDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return duration
+}
+
+// envInt reads an integer from the environment
+func envInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return number
+}
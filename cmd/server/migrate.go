@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"rss-aggregator/internal/database"
+)
+
+// migrateUsage describes the migrate subcommand
+const migrateUsage = `Usage: server migrate <command>
+
+Commands:
+  up      Apply all pending migrations
+  down    Roll back the latest migration
+  status  Show which migrations are applied`
+
+// runMigrate runs the migrate subcommand
+func runMigrate(ctx context.Context, db *database.DB, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected one command\n\n%s", migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := db.Migrate(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+		for _, migration := range applied {
+			fmt.Printf("Applied %s\n", migration)
+		}
+
+	case "down":
+		migration, err := db.MigrateDown(ctx)
+		if err != nil {
+			return err
+		}
+		if migration == nil {
+			fmt.Println("No migrations to roll back")
+			return nil
+		}
+		fmt.Printf("Rolled back %s\n", migration)
+
+	case "status":
+		statuses, err := db.MigrationStatus(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Applied At\tMigration")
+		for _, status := range statuses {
+			appliedAt := "Pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%s\t%s\n", appliedAt, status.Migration)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", args[0], migrateUsage)
+	}
+
+	return nil
+}
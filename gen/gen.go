@@ -4,9 +4,67 @@
 package api
 
 import (
+	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ArticleFormat.
+const (
+	ArticleFormatHtml ArticleFormat = "html"
+	ArticleFormatText ArticleFormat = "text"
+)
+
+// Defines values for ContentType.
+const (
+	ContentTypeHtml  ContentType = "html"
+	ContentTypeText  ContentType = "text"
+	ContentTypeXhtml ContentType = "xhtml"
+)
+
+// Defines values for FeedFormat.
+const (
+	FeedFormatAtom FeedFormat = "atom"
+	FeedFormatJson FeedFormat = "json"
+	FeedFormatRss  FeedFormat = "rss"
+)
+
+// Defines values for FeedStatus.
+const (
+	FeedStatusDisabled FeedStatus = "disabled"
+	FeedStatusFailing  FeedStatus = "failing"
+	FeedStatusOk       FeedStatus = "ok"
+	FeedStatusPending  FeedStatus = "pending"
+)
+
+// Defines values for JobState.
+const (
+	JobStateFailed    JobState = "failed"
+	JobStateQueued    JobState = "queued"
+	JobStateRunning   JobState = "running"
+	JobStateSucceeded JobState = "succeeded"
+)
+
+// Defines values for OPMLImportResultStatus.
+const (
+	OPMLImportResultStatusAdded  OPMLImportResultStatus = "added"
+	OPMLImportResultStatusExists OPMLImportResultStatus = "exists"
+	OPMLImportResultStatusFailed OPMLImportResultStatus = "failed"
+)
+
+// Defines values for WebhookDeliveryState.
+const (
+	WebhookDeliveryStateDead      WebhookDeliveryState = "dead"
+	WebhookDeliveryStateDelivered WebhookDeliveryState = "delivered"
+	WebhookDeliveryStatePending   WebhookDeliveryState = "pending"
+	WebhookDeliveryStateSending   WebhookDeliveryState = "sending"
 )
 
 // AddFeedRequest defines model for AddFeedRequest.
@@ -16,30 +74,608 @@ type AddFeedRequest struct {
 
 // Article defines model for Article.
 type Article struct {
-	Content         *string    `json:"content,omitempty"`
-	Id              *int       `json:"id,omitempty"`
-	IsRead          *bool      `json:"is_read,omitempty"`
+	Authors *[]string `json:"authors,omitempty"`
+
+	// BannerImage Баннер статьи из JSON Feed (banner_image)
+	BannerImage *string `json:"banner_image,omitempty"`
+
+	// Categories Рубрики статьи, заданные издателем
+	Categories *[]string `json:"categories,omitempty"`
+
+	// Content Текст статьи. HTML очищается при загрузке: остаются только
+	// разрешенные теги и атрибуты, скрипты, обработчики событий и ссылки
+	// javascript: удаляются, относительные адреса разрешаются от ссылки
+	// на статью, а ссылки получают rel="noopener"
+	Content     *string      `json:"content,omitempty"`
+	ContentType *ContentType `json:"content_type,omitempty"`
+	Enclosures  *[]Enclosure `json:"enclosures,omitempty"`
+
+	// Extensions Расширения JSON Feed - поля статьи, начинающиеся с "_"
+	Extensions *map[string]interface{} `json:"extensions,omitempty"`
+	FeedId     *int                    `json:"feed_id,omitempty"`
+	Id         *int                    `json:"id,omitempty"`
+	ImageUrl   *string                 `json:"image_url,omitempty"`
+	IsRead     *bool                   `json:"is_read,omitempty"`
+	IsStarred  *bool                   `json:"is_starred,omitempty"`
+	Link       *string                 `json:"link,omitempty"`
+
+	// PublicationDate Дата публикации, а если издатель ее не указал - дата изменения
 	PublicationDate *time.Time `json:"publication_date,omitempty"`
-	Title           *string    `json:"title,omitempty"`
+
+	// PublishedAt Дата публикации, указанная издателем
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+
+	// Summary Краткое описание, если лента дает его рядом с полным текстом. Всегда очищенный HTML, даже если content - простой текст; текстовое описание экранируется
+	Summary *string `json:"summary,omitempty"`
+	Title   *string `json:"title,omitempty"`
+
+	// UpdatedAt Время последнего изменения статьи у издателя
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ArticleFormat defines model for ArticleFormat.
+type ArticleFormat string
+
+// ArticleListResponse defines model for ArticleListResponse.
+type ArticleListResponse struct {
+	Articles *[]Article `json:"articles,omitempty"`
+
+	// NextCursor Курсор следующей страницы; отсутствует на последней странице
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// AuthResponse defines model for AuthResponse.
+type AuthResponse struct {
+	// Token API-токен для заголовка Authorization Bearer
+	Token string `json:"token"`
+	User  User   `json:"user"`
+}
+
+// Category defines model for Category.
+type Category struct {
+	// FeedIds Ленты категории
+	FeedIds *[]int  `json:"feed_ids,omitempty"`
+	Id      *int    `json:"id,omitempty"`
+	Name    *string `json:"name,omitempty"`
+
+	// UnreadCount Количество непрочитанных статей в лентах категории
+	UnreadCount *int `json:"unread_count,omitempty"`
+}
+
+// CategoryListResponse defines model for CategoryListResponse.
+type CategoryListResponse struct {
+	Categories *[]Category `json:"categories,omitempty"`
+}
+
+// CategoryRequest defines model for CategoryRequest.
+type CategoryRequest struct {
+	Name string `json:"name"`
+}
+
+// ContentType defines model for ContentType.
+type ContentType string
+
+// DiscoverResponse defines model for DiscoverResponse.
+type DiscoverResponse struct {
+	Candidates *[]FeedCandidate `json:"candidates,omitempty"`
+}
+
+// Enclosure defines model for Enclosure.
+type Enclosure struct {
+	// Length Размер вложения в байтах
+	Length *int64 `json:"length,omitempty"`
+
+	// Type MIME-тип вложения
+	Type *string `json:"type,omitempty"`
+	Url  string  `json:"url"`
+}
+
+// Feed defines model for Feed.
+type Feed struct {
+	Description *string     `json:"description,omitempty"`
+	Format      *FeedFormat `json:"format,omitempty"`
+
+	// FormatVersion Версия формата, например "2.0" для RSS 2.0
+	FormatVersion *string `json:"format_version,omitempty"`
+	Id            *int    `json:"id,omitempty"`
+	Title         *string `json:"title,omitempty"`
+	Url           *string `json:"url,omitempty"`
+}
+
+// FeedCandidate defines model for FeedCandidate.
+type FeedCandidate struct {
+	Title *string `json:"title,omitempty"`
+
+	// Type MIME-тип ленты
+	Type string `json:"type"`
+	Url  string `json:"url"`
+}
+
+// FeedFormat defines model for FeedFormat.
+type FeedFormat string
+
+// FeedHealth defines model for FeedHealth.
+type FeedHealth struct {
+	// ConsecutiveFailures Число неудачных загрузок подряд
+	ConsecutiveFailures *int `json:"consecutive_failures,omitempty"`
+
+	// DisabledAt Когда лента была отключена; отсутствует у включенной ленты
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	FeedId     *int       `json:"feed_id,omitempty"`
+
+	// LastError Ошибка последней загрузки
+	LastError     *string    `json:"last_error,omitempty"`
+	LastFetchedAt *time.Time `json:"last_fetched_at,omitempty"`
+
+	// LastHttpStatus HTTP-статус последнего ответа; отсутствует, если сервер ленты не ответил
+	LastHttpStatus *int `json:"last_http_status,omitempty"`
+
+	// LastParseError Ошибка разбора, если последняя загрузка получила не ленту или некорректный XML
+	LastParseError *string     `json:"last_parse_error,omitempty"`
+	LastSuccessAt  *time.Time  `json:"last_success_at,omitempty"`
+	NextFetchAt    *time.Time  `json:"next_fetch_at,omitempty"`
+	Status         *FeedStatus `json:"status,omitempty"`
+}
+
+// FeedListResponse defines model for FeedListResponse.
+type FeedListResponse struct {
+	Feeds  *[]Feed `json:"feeds,omitempty"`
+	Limit  *int    `json:"limit,omitempty"`
+	Offset *int    `json:"offset,omitempty"`
+
+	// Total Общее количество лент
+	Total *int `json:"total,omitempty"`
 }
 
 // FeedResponse defines model for FeedResponse.
 type FeedResponse struct {
-	Articles    *[]Article `json:"articles,omitempty"`
-	Description *string    `json:"description,omitempty"`
-	Id          *int       `json:"id,omitempty"`
-	Title       *string    `json:"title,omitempty"`
-	Url         *string    `json:"url,omitempty"`
+	Articles    *[]Article  `json:"articles,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	Format      *FeedFormat `json:"format,omitempty"`
+
+	// FormatVersion Версия формата, например "2.0" для RSS 2.0
+	FormatVersion *string `json:"format_version,omitempty"`
+	Id            *int    `json:"id,omitempty"`
+	Title         *string `json:"title,omitempty"`
+	Url           *string `json:"url,omitempty"`
+}
+
+// FeedStatus defines model for FeedStatus.
+type FeedStatus string
+
+// FeedTokenResponse defines model for FeedTokenResponse.
+type FeedTokenResponse struct {
+	// Token Токен лент для параметра token выходных лент; показывается один раз
+	Token string `json:"token"`
+}
+
+// Job defines model for Job.
+type Job struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Error Причина ошибки задачи в состоянии failed
+	Error *string `json:"error,omitempty"`
+
+	// FeedId Лента, на которую подписала задача в состоянии succeeded
+	FeedId     *int       `json:"feed_id,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Id         *int       `json:"id,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	State      *JobState  `json:"state,omitempty"`
+	Url        *string    `json:"url,omitempty"`
+}
+
+// JobState defines model for JobState.
+type JobState string
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// MarkReadBeforeRequest defines model for MarkReadBeforeRequest.
+type MarkReadBeforeRequest struct {
+	// Before Статьи, опубликованные раньше этого момента, будут отмечены прочитанными
+	Before time.Time `json:"before"`
+}
+
+// MarkReadResponse defines model for MarkReadResponse.
+type MarkReadResponse struct {
+	// Updated Количество статей, статус которых изменился
+	Updated *int `json:"updated,omitempty"`
+}
+
+// OPMLImportResponse defines model for OPMLImportResponse.
+type OPMLImportResponse struct {
+	// Added Количество добавленных лент
+	Added *int `json:"added,omitempty"`
+
+	// Existing Количество лент, которые уже были добавлены
+	Existing *int `json:"existing,omitempty"`
+
+	// Failed Количество лент, которые не удалось добавить
+	Failed  *int                `json:"failed,omitempty"`
+	Results *[]OPMLImportResult `json:"results,omitempty"`
+}
+
+// OPMLImportResult defines model for OPMLImportResult.
+type OPMLImportResult struct {
+	Categories *[]string `json:"categories,omitempty"`
+
+	// Error Причина ошибки для статуса failed
+	Error  *string                `json:"error,omitempty"`
+	FeedId *int                   `json:"feed_id,omitempty"`
+	Status OPMLImportResultStatus `json:"status"`
+	Url    string                 `json:"url"`
+}
+
+// RegisterRequest defines model for RegisterRequest.
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+// SearchResponse defines model for SearchResponse.
+type SearchResponse struct {
+	Limit   *int            `json:"limit,omitempty"`
+	Offset  *int            `json:"offset,omitempty"`
+	Results *[]SearchResult `json:"results,omitempty"`
+
+	// Total Общее количество найденных статей
+	Total *int `json:"total,omitempty"`
+}
+
+// SearchResult defines model for SearchResult.
+type SearchResult struct {
+	Article *Article `json:"article,omitempty"`
+
+	// Rank Оценка релевантности (bm25 в SQLite, ts_rank со знаком минус в PostgreSQL); чем меньше, тем релевантнее
+	Rank *float64 `json:"rank,omitempty"`
+
+	// Snippet Фрагмент текста вокруг лучшего совпадения, найденные слова обернуты в <mark></mark>
+	Snippet *string `json:"snippet,omitempty"`
+
+	// TitleHighlight Заголовок, в котором найденные слова обернуты в <mark></mark>
+	TitleHighlight *string `json:"title_highlight,omitempty"`
+}
+
+// UpdateArticleRequest defines model for UpdateArticleRequest.
+type UpdateArticleRequest struct {
+	IsRead    *bool `json:"is_read,omitempty"`
+	IsStarred *bool `json:"is_starred,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	Email string `json:"email"`
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	// CategoryId Категория, лентами которой ограничен вебхук
+	CategoryId *int       `json:"category_id,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+
+	// FeedId Лента, статьями которой ограничен вебхук
+	FeedId *int    `json:"feed_id,omitempty"`
+	Id     *int    `json:"id,omitempty"`
+	Url    *string `json:"url,omitempty"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	// Attempts Число сделанных попыток
+	Attempts    *int       `json:"attempts,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+
+	// Event Событие, например article.created
+	Event *string `json:"event,omitempty"`
+	Id    *int    `json:"id,omitempty"`
+
+	// LastError Ошибка последней неудачной попытки
+	LastError *string `json:"last_error,omitempty"`
+
+	// LastStatus HTTP-статус последней попытки; отсутствует, если получатель не ответил
+	LastStatus *int `json:"last_status,omitempty"`
+
+	// NextAttemptAt Когда будет сделана следующая попытка отправки в состоянии pending
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+
+	// Payload Отправляемое тело запроса
+	Payload   *map[string]interface{} `json:"payload,omitempty"`
+	State     *WebhookDeliveryState   `json:"state,omitempty"`
+	WebhookId *int                    `json:"webhook_id,omitempty"`
+}
+
+// WebhookDeliveryListResponse defines model for WebhookDeliveryListResponse.
+type WebhookDeliveryListResponse struct {
+	Deliveries *[]WebhookDelivery `json:"deliveries,omitempty"`
+	Limit      *int               `json:"limit,omitempty"`
+	Offset     *int               `json:"offset,omitempty"`
+
+	// Total Общее количество отправок вебхука
+	Total *int `json:"total,omitempty"`
+}
+
+// WebhookDeliveryState defines model for WebhookDeliveryState.
+type WebhookDeliveryState string
+
+// WebhookListResponse defines model for WebhookListResponse.
+type WebhookListResponse struct {
+	Webhooks *[]Webhook `json:"webhooks,omitempty"`
+}
+
+// WebhookRequest defines model for WebhookRequest.
+type WebhookRequest struct {
+	// CategoryId Отправлять только статьи лент этой категории
+	CategoryId *int `json:"category_id,omitempty"`
+
+	// FeedId Отправлять только статьи этой ленты
+	FeedId *int `json:"feed_id,omitempty"`
+
+	// Secret Ключ подписи X-Webhook-Signature; в ответах не возвращается
+	Secret string `json:"secret"`
+
+	// Url Адрес http или https, на который отправляются статьи
+	Url string `json:"url"`
+}
+
+// OPMLImportResultStatus defines model for OPMLImportResultStatus.
+type OPMLImportResultStatus string
+
+// GetArticlesParams defines parameters for GetArticles.
+type GetArticlesParams struct {
+	// FeedId Идентификаторы лент (можно указать несколько раз)
+	FeedId *[]int `form:"feed_id,omitempty" json:"feed_id,omitempty"`
+
+	// CategoryId Идентификаторы категорий; возвращаются статьи лент из любой из них
+	CategoryId *[]int `form:"category_id,omitempty" json:"category_id,omitempty"`
+
+	// IsRead Фильтр по статусу прочтения
+	IsRead *bool `form:"is_read,omitempty" json:"is_read,omitempty"`
+
+	// IsStarred Фильтр по отметке «избранное»
+	IsStarred *bool `form:"is_starred,omitempty" json:"is_starred,omitempty"`
+
+	// From Начало диапазона дат публикации (включительно)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец диапазона дат публикации (не включительно)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Q Подстрока для поиска в заголовке
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Cursor Курсор следующей страницы из поля next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Максимальное количество статей в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Format Вид content и summary статей - html (очищенный HTML, по умолчанию) или text (простой текст)
+	Format *ArticleFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetDiscoverParams defines parameters for GetDiscover.
+type GetDiscoverParams struct {
+	// Url Адрес страницы сайта или ленты (обязателен)
+	Url *string `form:"url,omitempty" json:"url,omitempty"`
+}
+
+// GetFeedsParams defines parameters for GetFeeds.
+type GetFeedsParams struct {
+	// Limit Максимальное количество лент в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Количество лент, которые нужно пропустить
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// PostFeedsParams defines parameters for PostFeeds.
+type PostFeedsParams struct {
+	// Async Добавить ленту в фоне и сразу вернуть задачу
+	Async *bool `form:"async,omitempty" json:"async,omitempty"`
+}
+
+// GetFeedsIdParams defines parameters for GetFeedsId.
+type GetFeedsIdParams struct {
+	// ArticlesLimit Максимальное количество последних статей в ответе
+	ArticlesLimit *int `form:"articles_limit,omitempty" json:"articles_limit,omitempty"`
+
+	// Format Вид content и summary статей - html (очищенный HTML, по умолчанию) или text (простой текст)
+	Format *ArticleFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetOutputNameParams defines parameters for GetOutputName.
+type GetOutputNameParams struct {
+	// Limit Максимальное количество статей в ленте
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Token Токен лент из POST /auth/feed-token вместо заголовка Authorization; API-токен здесь не принимается
+	Token *string `form:"token,omitempty" json:"token,omitempty"`
+}
+
+// GetSearchParams defines parameters for GetSearch.
+type GetSearchParams struct {
+	// Q Поисковый запрос (обязателен); все слова должны встретиться, слово с * на конце ищется как префикс
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// FeedId Идентификаторы лент (можно указать несколько раз)
+	FeedId *[]int `form:"feed_id,omitempty" json:"feed_id,omitempty"`
+
+	// IsRead Фильтр по статусу прочтения
+	IsRead *bool `form:"is_read,omitempty" json:"is_read,omitempty"`
+
+	// Limit Максимальное количество результатов в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Количество результатов, которые нужно пропустить
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Format Вид content и summary статей - html (очищенный HTML, по умолчанию) или text (простой текст)
+	Format *ArticleFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetStreamParams defines parameters for GetStream.
+type GetStreamParams struct {
+	// FeedId Идентификаторы лент (можно указать несколько раз)
+	FeedId *[]int `form:"feed_id,omitempty" json:"feed_id,omitempty"`
+
+	// CategoryId Идентификаторы категорий; приходят статьи лент из любой из них
+	CategoryId *[]int `form:"category_id,omitempty" json:"category_id,omitempty"`
+
+	// Format Вид content и summary статей - html (очищенный HTML, по умолчанию) или text (простой текст)
+	Format *ArticleFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetWebhooksIdDeliveriesParams defines parameters for GetWebhooksIdDeliveries.
+type GetWebhooksIdDeliveriesParams struct {
+	// Limit Максимальное количество отправок в ответе
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Количество отправок, которые нужно пропустить
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
+// PostArticlesMarkReadJSONRequestBody defines body for PostArticlesMarkRead for application/json ContentType.
+type PostArticlesMarkReadJSONRequestBody = MarkReadBeforeRequest
+
+// PatchArticlesIdJSONRequestBody defines body for PatchArticlesId for application/json ContentType.
+type PatchArticlesIdJSONRequestBody = UpdateArticleRequest
+
+// PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
+type PostAuthLoginJSONRequestBody = LoginRequest
+
+// PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
+type PostAuthRegisterJSONRequestBody = RegisterRequest
+
+// PostCategoriesJSONRequestBody defines body for PostCategories for application/json ContentType.
+type PostCategoriesJSONRequestBody = CategoryRequest
+
+// PatchCategoriesIdJSONRequestBody defines body for PatchCategoriesId for application/json ContentType.
+type PatchCategoriesIdJSONRequestBody = CategoryRequest
+
 // PostFeedsJSONRequestBody defines body for PostFeeds for application/json ContentType.
 type PostFeedsJSONRequestBody = AddFeedRequest
 
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody = WebhookRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Получить статьи из всех лент с фильтрами
+	// (GET /articles)
+	GetArticles(c *fiber.Ctx, params GetArticlesParams) error
+	// Отметить прочитанными все статьи, опубликованные раньше указанного момента
+	// (POST /articles/mark-read)
+	PostArticlesMarkRead(c *fiber.Ctx) error
+	// Изменить статус прочтения и отметку «избранное» статьи
+	// (PATCH /articles/{id})
+	PatchArticlesId(c *fiber.Ctx, id int) error
+	// Отозвать токен лент
+	// (DELETE /auth/feed-token)
+	DeleteAuthFeedToken(c *fiber.Ctx) error
+	// Выдать токен лент для выходных лент, заменив прежний
+	// (POST /auth/feed-token)
+	PostAuthFeedToken(c *fiber.Ctx) error
+	// Выдать новый API-токен по email и паролю
+	// (POST /auth/login)
+	PostAuthLogin(c *fiber.Ctx) error
+	// Отозвать токен, которым подписан запрос
+	// (POST /auth/logout)
+	PostAuthLogout(c *fiber.Ctx) error
+	// Получить текущего пользователя
+	// (GET /auth/me)
+	GetAuthMe(c *fiber.Ctx) error
+	// Зарегистрировать пользователя и выдать API-токен
+	// (POST /auth/register)
+	PostAuthRegister(c *fiber.Ctx) error
+	// Получить список категорий с количеством непрочитанных статей
+	// (GET /categories)
+	GetCategories(c *fiber.Ctx) error
+	// Создать категорию
+	// (POST /categories)
+	PostCategories(c *fiber.Ctx) error
+	// Удалить категорию; ленты категории остаются
+	// (DELETE /categories/{id})
+	DeleteCategoriesId(c *fiber.Ctx, id int) error
+	// Получить категорию
+	// (GET /categories/{id})
+	GetCategoriesId(c *fiber.Ctx, id int) error
+	// Переименовать категорию
+	// (PATCH /categories/{id})
+	PatchCategoriesId(c *fiber.Ctx, id int) error
+	// Убрать ленту из категории
+	// (DELETE /categories/{id}/feeds/{feed_id})
+	DeleteCategoriesIdFeedsFeedId(c *fiber.Ctx, id int, feedId int) error
+	// Добавить ленту в категорию
+	// (PUT /categories/{id}/feeds/{feed_id})
+	PutCategoriesIdFeedsFeedId(c *fiber.Ctx, id int, feedId int) error
+	// Отметить прочитанными все статьи лент категории
+	// (POST /categories/{id}/mark-read)
+	PostCategoriesIdMarkRead(c *fiber.Ctx, id int) error
+	// Найти RSS-ленты на странице сайта
+	// (GET /discover)
+	GetDiscover(c *fiber.Ctx, params GetDiscoverParams) error
+	// Получить список RSS-лент
+	// (GET /feeds)
+	GetFeeds(c *fiber.Ctx, params GetFeedsParams) error
 	// Добавить новую RSS-ленту
 	// (POST /feeds)
-	PostFeeds(c *fiber.Ctx) error
+	PostFeeds(c *fiber.Ctx, params PostFeedsParams) error
+	// Удалить RSS-ленту вместе со статьями
+	// (DELETE /feeds/{id})
+	DeleteFeedsId(c *fiber.Ctx, id int) error
+	// Получить RSS-ленту с последними статьями
+	// (GET /feeds/{id})
+	GetFeedsId(c *fiber.Ctx, id int, params GetFeedsIdParams) error
+	// Включить отключенную ленту
+	// (POST /feeds/{id}/enable)
+	PostFeedsIdEnable(c *fiber.Ctx, id int) error
+	// Получить состояние загрузок ленты
+	// (GET /feeds/{id}/health)
+	GetFeedsIdHealth(c *fiber.Ctx, id int) error
+	// Отметить прочитанными все статьи ленты
+	// (POST /feeds/{id}/mark-read)
+	PostFeedsIdMarkRead(c *fiber.Ctx, id int) error
+	// Получить состояние задачи добавления ленты
+	// (GET /jobs/{id})
+	GetJobsId(c *fiber.Ctx, id int) error
+	// Экспортировать подписки в OPML 2.0
+	// (GET /opml/export)
+	GetOpmlExport(c *fiber.Ctx) error
+	// Импортировать подписки из OPML
+	// (POST /opml/import)
+	PostOpmlImport(c *fiber.Ctx) error
+	// Получить сводную ленту статей в формате RSS 2.0, Atom или JSON Feed
+	// (GET /output/{name})
+	GetOutputName(c *fiber.Ctx, name string, params GetOutputNameParams) error
+	// Полнотекстовый поиск по заголовкам и текстам статей
+	// (GET /search)
+	GetSearch(c *fiber.Ctx, params GetSearchParams) error
+	// Получать новые статьи потоком Server-Sent Events
+	// (GET /stream)
+	GetStream(c *fiber.Ctx, params GetStreamParams) error
+	// Получить список вебхуков
+	// (GET /webhooks)
+	GetWebhooks(c *fiber.Ctx) error
+	// Подписать вебхук на новые статьи
+	// (POST /webhooks)
+	PostWebhooks(c *fiber.Ctx) error
+	// Удалить вебхук вместе с журналом отправок
+	// (DELETE /webhooks/{id})
+	DeleteWebhooksId(c *fiber.Ctx, id int) error
+	// Получить вебхук
+	// (GET /webhooks/{id})
+	GetWebhooksId(c *fiber.Ctx, id int) error
+	// Получить журнал отправок вебхука, начиная с новых
+	// (GET /webhooks/{id}/deliveries)
+	GetWebhooksIdDeliveries(c *fiber.Ctx, id int, params GetWebhooksIdDeliveriesParams) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -49,33 +685,863 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc fiber.Handler
 
-// PostFeeds operation middleware
-func (siw *ServerInterfaceWrapper) PostFeeds(c *fiber.Ctx) error {
+// GetArticles operation middleware
+func (siw *ServerInterfaceWrapper) GetArticles(c *fiber.Ctx) error {
 
-	return siw.Handler.PostFeeds(c)
-}
+	var err error
 
-// FiberServerOptions provides options for the Fiber server.
-type FiberServerOptions struct {
-	BaseURL     string
-	Middlewares []MiddlewareFunc
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetArticlesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "feed_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "feed_id", query, &params.FeedId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter feed_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "category_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category_id", query, &params.CategoryId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter category_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "is_read" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_read", query, &params.IsRead)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter is_read: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "is_starred" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_starred", query, &params.IsStarred)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter is_starred: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", query, &params.Q)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter q: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", query, &params.Cursor)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter cursor: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	return siw.Handler.GetArticles(c, params)
 }
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router fiber.Router, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
+// PostArticlesMarkRead operation middleware
+func (siw *ServerInterfaceWrapper) PostArticlesMarkRead(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostArticlesMarkRead(c)
 }
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+// PatchArticlesId operation middleware
+func (siw *ServerInterfaceWrapper) PatchArticlesId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
 	}
 
-	for _, m := range options.Middlewares {
-		router.Use(fiber.Handler(m))
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PatchArticlesId(c, id)
+}
+
+// DeleteAuthFeedToken operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAuthFeedToken(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteAuthFeedToken(c)
+}
+
+// PostAuthFeedToken operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthFeedToken(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostAuthFeedToken(c)
+}
+
+// PostAuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogin(c *fiber.Ctx) error {
+
+	return siw.Handler.PostAuthLogin(c)
+}
+
+// PostAuthLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogout(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostAuthLogout(c)
+}
+
+// GetAuthMe operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthMe(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetAuthMe(c)
+}
+
+// PostAuthRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthRegister(c *fiber.Ctx) error {
+
+	return siw.Handler.PostAuthRegister(c)
+}
+
+// GetCategories operation middleware
+func (siw *ServerInterfaceWrapper) GetCategories(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetCategories(c)
+}
+
+// PostCategories operation middleware
+func (siw *ServerInterfaceWrapper) PostCategories(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostCategories(c)
+}
+
+// DeleteCategoriesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCategoriesId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
 	}
 
-	router.Post(options.BaseURL+"/feeds", wrapper.PostFeeds)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteCategoriesId(c, id)
+}
+
+// GetCategoriesId operation middleware
+func (siw *ServerInterfaceWrapper) GetCategoriesId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetCategoriesId(c, id)
+}
+
+// PatchCategoriesId operation middleware
+func (siw *ServerInterfaceWrapper) PatchCategoriesId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PatchCategoriesId(c, id)
+}
+
+// DeleteCategoriesIdFeedsFeedId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCategoriesIdFeedsFeedId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	// ------------- Path parameter "feed_id" -------------
+	var feedId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "feed_id", c.Params("feed_id"), &feedId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter feed_id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteCategoriesIdFeedsFeedId(c, id, feedId)
+}
+
+// PutCategoriesIdFeedsFeedId operation middleware
+func (siw *ServerInterfaceWrapper) PutCategoriesIdFeedsFeedId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	// ------------- Path parameter "feed_id" -------------
+	var feedId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "feed_id", c.Params("feed_id"), &feedId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter feed_id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutCategoriesIdFeedsFeedId(c, id, feedId)
+}
+
+// PostCategoriesIdMarkRead operation middleware
+func (siw *ServerInterfaceWrapper) PostCategoriesIdMarkRead(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostCategoriesIdMarkRead(c, id)
+}
+
+// GetDiscover operation middleware
+func (siw *ServerInterfaceWrapper) GetDiscover(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDiscoverParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "url" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "url", query, &params.Url)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter url: %w", err).Error())
+	}
+
+	return siw.Handler.GetDiscover(c, params)
+}
+
+// GetFeeds operation middleware
+func (siw *ServerInterfaceWrapper) GetFeeds(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFeedsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", query, &params.Offset)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter offset: %w", err).Error())
+	}
+
+	return siw.Handler.GetFeeds(c, params)
+}
+
+// PostFeeds operation middleware
+func (siw *ServerInterfaceWrapper) PostFeeds(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostFeedsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "async" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "async", query, &params.Async)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter async: %w", err).Error())
+	}
+
+	return siw.Handler.PostFeeds(c, params)
+}
+
+// DeleteFeedsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFeedsId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteFeedsId(c, id)
+}
+
+// GetFeedsId operation middleware
+func (siw *ServerInterfaceWrapper) GetFeedsId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFeedsIdParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "articles_limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "articles_limit", query, &params.ArticlesLimit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter articles_limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	return siw.Handler.GetFeedsId(c, id, params)
+}
+
+// PostFeedsIdEnable operation middleware
+func (siw *ServerInterfaceWrapper) PostFeedsIdEnable(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostFeedsIdEnable(c, id)
+}
+
+// GetFeedsIdHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetFeedsIdHealth(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetFeedsIdHealth(c, id)
+}
+
+// PostFeedsIdMarkRead operation middleware
+func (siw *ServerInterfaceWrapper) PostFeedsIdMarkRead(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostFeedsIdMarkRead(c, id)
+}
+
+// GetJobsId operation middleware
+func (siw *ServerInterfaceWrapper) GetJobsId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetJobsId(c, id)
+}
+
+// GetOpmlExport operation middleware
+func (siw *ServerInterfaceWrapper) GetOpmlExport(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetOpmlExport(c)
+}
+
+// PostOpmlImport operation middleware
+func (siw *ServerInterfaceWrapper) PostOpmlImport(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostOpmlImport(c)
+}
+
+// GetOutputName operation middleware
+func (siw *ServerInterfaceWrapper) GetOutputName(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Params("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter name: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOutputNameParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "token" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "token", query, &params.Token)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter token: %w", err).Error())
+	}
+
+	return siw.Handler.GetOutputName(c, name, params)
+}
+
+// GetSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetSearch(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSearchParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", query, &params.Q)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter q: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "feed_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "feed_id", query, &params.FeedId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter feed_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "is_read" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_read", query, &params.IsRead)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter is_read: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", query, &params.Offset)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter offset: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	return siw.Handler.GetSearch(c, params)
+}
+
+// GetStream operation middleware
+func (siw *ServerInterfaceWrapper) GetStream(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStreamParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "feed_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "feed_id", query, &params.FeedId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter feed_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "category_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category_id", query, &params.CategoryId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter category_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	return siw.Handler.GetStream(c, params)
+}
+
+// GetWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooks(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetWebhooks(c)
+}
+
+// PostWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooks(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostWebhooks(c)
+}
+
+// DeleteWebhooksId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhooksId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteWebhooksId(c, id)
+}
+
+// GetWebhooksId operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetWebhooksId(c, id)
+}
+
+// GetWebhooksIdDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksIdDeliveries(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhooksIdDeliveriesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", query, &params.Offset)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter offset: %w", err).Error())
+	}
+
+	return siw.Handler.GetWebhooksIdDeliveries(c, id, params)
+}
+
+// FiberServerOptions provides options for the Fiber server.
+type FiberServerOptions struct {
+	BaseURL     string
+	Middlewares []MiddlewareFunc
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router fiber.Router, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	for _, m := range options.Middlewares {
+		router.Use(fiber.Handler(m))
+	}
+
+	router.Get(options.BaseURL+"/articles", wrapper.GetArticles)
+
+	router.Post(options.BaseURL+"/articles/mark-read", wrapper.PostArticlesMarkRead)
+
+	router.Patch(options.BaseURL+"/articles/:id", wrapper.PatchArticlesId)
+
+	router.Delete(options.BaseURL+"/auth/feed-token", wrapper.DeleteAuthFeedToken)
+
+	router.Post(options.BaseURL+"/auth/feed-token", wrapper.PostAuthFeedToken)
+
+	router.Post(options.BaseURL+"/auth/login", wrapper.PostAuthLogin)
+
+	router.Post(options.BaseURL+"/auth/logout", wrapper.PostAuthLogout)
+
+	router.Get(options.BaseURL+"/auth/me", wrapper.GetAuthMe)
+
+	router.Post(options.BaseURL+"/auth/register", wrapper.PostAuthRegister)
+
+	router.Get(options.BaseURL+"/categories", wrapper.GetCategories)
+
+	router.Post(options.BaseURL+"/categories", wrapper.PostCategories)
+
+	router.Delete(options.BaseURL+"/categories/:id", wrapper.DeleteCategoriesId)
+
+	router.Get(options.BaseURL+"/categories/:id", wrapper.GetCategoriesId)
+
+	router.Patch(options.BaseURL+"/categories/:id", wrapper.PatchCategoriesId)
+
+	router.Delete(options.BaseURL+"/categories/:id/feeds/:feed_id", wrapper.DeleteCategoriesIdFeedsFeedId)
+
+	router.Put(options.BaseURL+"/categories/:id/feeds/:feed_id", wrapper.PutCategoriesIdFeedsFeedId)
+
+	router.Post(options.BaseURL+"/categories/:id/mark-read", wrapper.PostCategoriesIdMarkRead)
+
+	router.Get(options.BaseURL+"/discover", wrapper.GetDiscover)
+
+	router.Get(options.BaseURL+"/feeds", wrapper.GetFeeds)
+
+	router.Post(options.BaseURL+"/feeds", wrapper.PostFeeds)
+
+	router.Delete(options.BaseURL+"/feeds/:id", wrapper.DeleteFeedsId)
+
+	router.Get(options.BaseURL+"/feeds/:id", wrapper.GetFeedsId)
+
+	router.Post(options.BaseURL+"/feeds/:id/enable", wrapper.PostFeedsIdEnable)
+
+	router.Get(options.BaseURL+"/feeds/:id/health", wrapper.GetFeedsIdHealth)
+
+	router.Post(options.BaseURL+"/feeds/:id/mark-read", wrapper.PostFeedsIdMarkRead)
+
+	router.Get(options.BaseURL+"/jobs/:id", wrapper.GetJobsId)
+
+	router.Get(options.BaseURL+"/opml/export", wrapper.GetOpmlExport)
+
+	router.Post(options.BaseURL+"/opml/import", wrapper.PostOpmlImport)
+
+	router.Get(options.BaseURL+"/output/:name", wrapper.GetOutputName)
+
+	router.Get(options.BaseURL+"/search", wrapper.GetSearch)
+
+	router.Get(options.BaseURL+"/stream", wrapper.GetStream)
+
+	router.Get(options.BaseURL+"/webhooks", wrapper.GetWebhooks)
+
+	router.Post(options.BaseURL+"/webhooks", wrapper.PostWebhooks)
+
+	router.Delete(options.BaseURL+"/webhooks/:id", wrapper.DeleteWebhooksId)
+
+	router.Get(options.BaseURL+"/webhooks/:id", wrapper.GetWebhooksId)
+
+	router.Get(options.BaseURL+"/webhooks/:id/deliveries", wrapper.GetWebhooksIdDeliveries)
 
 }
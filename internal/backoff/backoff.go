@@ -0,0 +1,19 @@
+// Package backoff picks the pauses before retrying work that keeps failing
+package backoff
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Delay returns the pause after failures failed attempts in a row: base,
+// doubled with every failure after the first and capped at limit, with up to
+// 20% jitter either way so that work failing together spreads out
+func Delay(base, limit time.Duration, failures int) time.Duration {
+	delay := base
+	for i := 1; i < failures && delay < limit; i++ {
+		delay *= 2
+	}
+	delay += rand.N(delay/5*2+1) - delay/5
+	return min(delay, limit)
+}
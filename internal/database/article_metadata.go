@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// joinAuthors stores author names one per line
+func joinAuthors(authors []string) string {
+	return strings.Join(authors, "\n")
+}
+
+// splitAuthors reverses joinAuthors
+func splitAuthors(authors *string) []string {
+	if authors == nil || *authors == "" {
+		return nil
+	}
+	return strings.Split(*authors, "\n")
+}
+
+// replaceArticleMetadata replaces the categories and enclosures of an article
+func replaceArticleMetadata(ctx context.Context, tx *sqlTx, articleID int, categories []string, enclosures []Enclosure) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM article_tags WHERE article_id = ?", articleID); err != nil {
+		return err
+	}
+	for _, tag := range categories {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, "INSERT INTO article_tags (article_id, tag) VALUES (?, ?) ON CONFLICT DO NOTHING", articleID, tag)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM article_enclosures WHERE article_id = ?", articleID); err != nil {
+		return err
+	}
+	for _, enclosure := range enclosures {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO article_enclosures (article_id, url, type, length) VALUES (?, ?, ?, ?)",
+			articleID, enclosure.URL, enclosure.Type, enclosure.Length,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadArticleMetadata fills in the categories and enclosures of the given articles
+func (db *DB) loadArticleMetadata(ctx context.Context, articles []Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	index := make(map[int]*Article, len(articles))
+	args := make([]any, 0, len(articles))
+	for i := range articles {
+		index[articles[i].ID] = &articles[i]
+		args = append(args, articles[i].ID)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+
+	rows, err := db.conn.QueryContext(
+		ctx,
+		fmt.Sprintf("SELECT article_id, tag FROM article_tags WHERE article_id IN (%s) ORDER BY tag", placeholders),
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var (
+			articleID int
+			tag       string
+		)
+		if err := rows.Scan(&articleID, &tag); err != nil {
+			rows.Close()
+			return err
+		}
+		article := index[articleID]
+		article.Categories = append(article.Categories, tag)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = db.conn.QueryContext(
+		ctx,
+		fmt.Sprintf("SELECT article_id, url, type, length FROM article_enclosures WHERE article_id IN (%s) ORDER BY id", placeholders),
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			articleID int
+			enclosure Enclosure
+		)
+		if err := rows.Scan(&articleID, &enclosure.URL, &enclosure.Type, &enclosure.Length); err != nil {
+			return err
+		}
+		article := index[articleID]
+		article.Enclosures = append(article.Enclosures, enclosure)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,314 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// articleColumns lists the columns scanned by scanArticles. They are selected
+// from articleSource, which adds the reading state of one user to each article.
+const articleColumns = "a.id, a.feed_id, a.guid, a.link, a.title, a.content, a.raw_content, a.content_type, a.summary, a.publication_date, a.published_at, " +
+	"a.updated_at, a.authors, a.image_url, a.banner_image, a.extensions, " +
+	"COALESCE(ua.is_read, FALSE), COALESCE(ua.is_starred, FALSE)"
+
+// articleSource joins articles with the state of the user bound to its placeholder
+const articleSource = "articles a LEFT JOIN user_articles ua ON ua.article_id = a.id AND ua.user_id = ?"
+
+// subscribedArticles restricts articles to the feeds the user bound to its placeholder subscribes to
+const subscribedArticles = "a.feed_id IN (SELECT feed_id FROM user_feeds WHERE user_id = ?)"
+
+// ArticleCursor points at the last article of a page in (publication_date, id) order
+type ArticleCursor struct {
+	PublicationDate *time.Time
+	ID              int
+}
+
+// ArticleFilter describes an article query across feeds
+type ArticleFilter struct {
+	// UserID selects whose subscriptions and reading state are used
+	UserID      int
+	FeedIDs     []int
+	CategoryIDs []int
+	IsRead      *bool
+	IsStarred   *bool
+	From        *time.Time // inclusive lower bound on publication_date
+	To          *time.Time // exclusive upper bound on publication_date
+	TitleQuery  string
+	After       *ArticleCursor
+	Limit       int
+}
+
+// ListArticles retrieves a page of articles matching the filter, newest first.
+// The returned cursor is nil when there are no more articles.
+func (db *DB) ListArticles(ctx context.Context, filter ArticleFilter) ([]Article, *ArticleCursor, error) {
+	conditions := []string{subscribedArticles}
+	args := []any{filter.UserID, filter.UserID}
+
+	conditions, args = appendFeedConditions(conditions, args, filter.FeedIDs, filter.CategoryIDs)
+	if filter.IsRead != nil {
+		conditions = append(conditions, "COALESCE(ua.is_read, FALSE) = ?")
+		args = append(args, *filter.IsRead)
+	}
+	if filter.IsStarred != nil {
+		conditions = append(conditions, "COALESCE(ua.is_starred, FALSE) = ?")
+		args = append(args, *filter.IsStarred)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "a.publication_date >= ?")
+		args = append(args, filter.From.UTC())
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "a.publication_date < ?")
+		args = append(args, filter.To.UTC())
+	}
+	if filter.TitleQuery != "" {
+		conditions = append(conditions, `LOWER(a.title) LIKE LOWER(?) ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(filter.TitleQuery)+"%")
+	}
+	if filter.After != nil {
+		// Articles without a publication date sort last, after every dated one
+		if filter.After.PublicationDate != nil {
+			date := filter.After.PublicationDate.UTC()
+			conditions = append(conditions, "(a.publication_date < ? OR (a.publication_date = ? AND a.id < ?) OR a.publication_date IS NULL)")
+			args = append(args, date, date, filter.After.ID)
+		} else {
+			conditions = append(conditions, "(a.publication_date IS NULL AND a.id < ?)")
+			args = append(args, filter.After.ID)
+		}
+	}
+
+	// Fetch one extra row to learn whether another page exists
+	query := "SELECT " + articleColumns + " FROM " + articleSource +
+		" WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY a.publication_date DESC NULLS LAST, a.id DESC LIMIT ?"
+	args = append(args, filter.Limit+1)
+
+	articles, err := db.queryArticles(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(articles) <= filter.Limit {
+		return articles, nil, nil
+	}
+
+	articles = articles[:filter.Limit]
+	last := articles[len(articles)-1]
+	return articles, &ArticleCursor{PublicationDate: last.PublicationDate, ID: last.ID}, nil
+}
+
+// NewArticleFilter describes the articles added after a known one, as
+// followed by a stream
+type NewArticleFilter struct {
+	// UserID selects whose subscriptions and reading state are used
+	UserID      int
+	FeedIDs     []int
+	CategoryIDs []int
+	// AfterID is the ID of the last article already seen
+	AfterID int
+	Limit   int
+}
+
+// ListNewArticles retrieves the articles matching the filter with an ID
+// above AfterID, in ID order. IDs grow in insertion order but not in commit
+// order: on PostgreSQL a lower ID can become visible after a higher one, so
+// a caller following new articles re-reads a trailing window of recent IDs.
+func (db *DB) ListNewArticles(ctx context.Context, filter NewArticleFilter) ([]Article, error) {
+	conditions := []string{subscribedArticles, "a.id > ?"}
+	args := []any{filter.UserID, filter.UserID, filter.AfterID}
+	conditions, args = appendFeedConditions(conditions, args, filter.FeedIDs, filter.CategoryIDs)
+
+	query := "SELECT " + articleColumns + " FROM " + articleSource +
+		" WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY a.id LIMIT ?"
+	return db.queryArticles(ctx, query, append(args, filter.Limit)...)
+}
+
+// LatestArticleID returns the highest article ID, 0 when there are no articles
+func (db *DB) LatestArticleID(ctx context.Context) (int, error) {
+	var id int
+	err := db.conn.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM articles").Scan(&id)
+	return id, err
+}
+
+// appendFeedConditions restricts an article query to the given feeds and to
+// the feeds of the given categories; empty lists leave it unrestricted
+func appendFeedConditions(conditions []string, args []any, feedIDs, categoryIDs []int) ([]string, []any) {
+	if len(feedIDs) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(feedIDs)), ", ")
+		conditions = append(conditions, fmt.Sprintf("a.feed_id IN (%s)", placeholders))
+		for _, id := range feedIDs {
+			args = append(args, id)
+		}
+	}
+	if len(categoryIDs) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(categoryIDs)), ", ")
+		conditions = append(conditions, fmt.Sprintf("a.feed_id IN (SELECT feed_id FROM feed_categories WHERE category_id IN (%s))", placeholders))
+		for _, id := range categoryIDs {
+			args = append(args, id)
+		}
+	}
+	return conditions, args
+}
+
+// queryArticles runs a query selecting articleColumns and loads the
+// categories and enclosures of the returned articles
+func (db *DB) queryArticles(ctx context.Context, query string, args ...any) ([]Article, error) {
+	rows, err := db.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	articles, err := scanArticles(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.loadArticleMetadata(ctx, articles); err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// scanArticles reads all rows selected with articleColumns
+func scanArticles(rows *sql.Rows) ([]Article, error) {
+	var articles []Article
+	for rows.Next() {
+		article, err := scanArticle(rows)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, article)
+	}
+
+	return articles, rows.Err()
+}
+
+// scanArticle reads a row selected with articleColumns followed by extra columns
+func scanArticle(rows *sql.Rows, extra ...any) (Article, error) {
+	var (
+		article Article
+		authors *string
+	)
+	dest := []any{
+		&article.ID,
+		&article.FeedID,
+		&article.GUID,
+		&article.Link,
+		&article.Title,
+		&article.Content,
+		&article.RawContent,
+		&article.ContentType,
+		&article.Summary,
+		&article.PublicationDate,
+		&article.PublishedAt,
+		&article.UpdatedAt,
+		&authors,
+		&article.ImageURL,
+		&article.BannerImage,
+		&article.Extensions,
+		&article.IsRead,
+		&article.IsStarred,
+	}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return Article{}, err
+	}
+	article.Authors = splitAuthors(authors)
+
+	return article, nil
+}
+
+// escapeLike escapes LIKE wildcards so the value matches literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// GetArticleByID retrieves an article from the feeds a user subscribes to.
+// It returns nil if there is no such article.
+func (db *DB) GetArticleByID(ctx context.Context, userID, id int) (*Article, error) {
+	articles, err := db.queryArticles(
+		ctx,
+		"SELECT "+articleColumns+" FROM "+articleSource+" WHERE a.id = ? AND "+subscribedArticles,
+		userID, id, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(articles) == 0 {
+		return nil, nil
+	}
+
+	return &articles[0], nil
+}
+
+// SetArticleState changes the read and starred state of an article for a user
+// and returns the updated article; nil values are left unchanged.
+// It returns nil if the article is not in the feeds the user subscribes to.
+func (db *DB) SetArticleState(ctx context.Context, userID, id int, isRead, isStarred *bool) (*Article, error) {
+	article, err := db.GetArticleByID(ctx, userID, id)
+	if err != nil || article == nil {
+		return nil, err
+	}
+
+	_, err = db.conn.ExecContext(
+		ctx,
+		`INSERT INTO user_articles (user_id, article_id, is_read, is_starred)
+		VALUES (?, ?, COALESCE(?, FALSE), COALESCE(?, FALSE))
+		ON CONFLICT (user_id, article_id) DO UPDATE SET
+			is_read = COALESCE(?, is_read),
+			is_starred = COALESCE(?, is_starred)`,
+		userID, id, isRead, isStarred, isRead, isStarred,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetArticleByID(ctx, userID, id)
+}
+
+// MarkFeedArticlesRead marks every unread article of a feed as read for a user
+// and returns the number of changed articles
+func (db *DB) MarkFeedArticlesRead(ctx context.Context, userID, feedID int) (int64, error) {
+	return db.markArticlesRead(ctx, userID, "a.feed_id = ?", feedID)
+}
+
+// MarkCategoryArticlesRead marks every unread article of the feeds in a category
+// as read for a user and returns the number of changed articles
+func (db *DB) MarkCategoryArticlesRead(ctx context.Context, userID, categoryID int) (int64, error) {
+	return db.markArticlesRead(
+		ctx,
+		userID,
+		"a.feed_id IN (SELECT feed_id FROM feed_categories WHERE category_id = ?)",
+		categoryID,
+	)
+}
+
+// MarkArticlesReadBefore marks every unread article published before the given
+// time as read for a user and returns the number of changed articles
+func (db *DB) MarkArticlesReadBefore(ctx context.Context, userID int, before time.Time) (int64, error) {
+	return db.markArticlesRead(ctx, userID, "a.publication_date < ?", before.UTC())
+}
+
+// markArticlesRead marks the subscribed articles matching condition as read for
+// a user. Articles without a state row are unread, so inserting one counts as a change.
+func (db *DB) markArticlesRead(ctx context.Context, userID int, condition string, args ...any) (int64, error) {
+	query := `INSERT INTO user_articles (user_id, article_id, is_read)
+		SELECT CAST(? AS INTEGER), a.id, TRUE FROM articles a WHERE ` + condition + " AND " + subscribedArticles + `
+		ON CONFLICT (user_id, article_id) DO UPDATE SET is_read = TRUE WHERE user_articles.is_read = FALSE`
+
+	return db.execRowsAffected(ctx, query, append(append([]any{userID}, args...), userID)...)
+}
+
+// execRowsAffected runs a statement and returns the number of affected rows
+func (db *DB) execRowsAffected(ctx context.Context, query string, args ...any) (int64, error) {
+	result, err := db.conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
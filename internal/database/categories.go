@@ -0,0 +1,260 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Category represents a category in the database
+type Category struct {
+	ID   int
+	Name string
+}
+
+// CategorySummary is a category with the feeds it contains and their unread articles
+type CategorySummary struct {
+	Category
+	FeedIDs     []int
+	UnreadCount int
+}
+
+// GetCategoryByID retrieves a category of a user by its ID
+func (db *DB) GetCategoryByID(ctx context.Context, userID, id int) (*Category, error) {
+	return scanCategory(db.conn.QueryRowContext(
+		ctx,
+		"SELECT id, name FROM categories WHERE id = ? AND user_id = ?",
+		id, userID,
+	))
+}
+
+// GetCategoryByName retrieves a category of a user by its name
+func (db *DB) GetCategoryByName(ctx context.Context, userID int, name string) (*Category, error) {
+	return scanCategory(db.conn.QueryRowContext(
+		ctx,
+		"SELECT id, name FROM categories WHERE name = ? AND user_id = ?",
+		name, userID,
+	))
+}
+
+// scanCategory reads a category row, returning nil if there is none
+func scanCategory(row *sql.Row) (*Category, error) {
+	var category Category
+	err := row.Scan(&category.ID, &category.Name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// CreateCategory creates a new category owned by a user
+func (db *DB) CreateCategory(ctx context.Context, userID int, name string) (*Category, error) {
+	var id int
+	err := db.conn.QueryRowContext(
+		ctx,
+		"INSERT INTO categories (user_id, name) VALUES (?, ?) RETURNING id",
+		userID, name,
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Category{ID: id, Name: name}, nil
+}
+
+// ListCategories retrieves all categories of a user ordered by name
+func (db *DB) ListCategories(ctx context.Context, userID int) ([]Category, error) {
+	rows, err := db.conn.QueryContext(ctx, "SELECT id, name FROM categories WHERE user_id = ? ORDER BY name", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []Category
+	for rows.Next() {
+		var category Category
+		if err := rows.Scan(&category.ID, &category.Name); err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+// RenameCategory changes the name of a category. It reports false if the category does not exist.
+func (db *DB) RenameCategory(ctx context.Context, id int, name string) (bool, error) {
+	affected, err := db.execRowsAffected(ctx, "UPDATE categories SET name = ? WHERE id = ?", name, id)
+	return affected > 0, err
+}
+
+// DeleteCategory deletes a category and the webhooks limited to it; its feeds are kept.
+// It reports false if the category does not exist.
+func (db *DB) DeleteCategory(ctx context.Context, id int) (bool, error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM feed_categories WHERE category_id = ?", id); err != nil {
+		return false, err
+	}
+	if _, err := deleteWebhooks(ctx, tx, "category_id = ?", id); err != nil {
+		return false, err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM categories WHERE id = ?", id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	return true, tx.Commit()
+}
+
+// AddFeedToCategory puts a feed into a category; adding it twice is not an error
+func (db *DB) AddFeedToCategory(ctx context.Context, feedID, categoryID int) error {
+	_, err := db.conn.ExecContext(
+		ctx,
+		"INSERT INTO feed_categories (feed_id, category_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+		feedID, categoryID,
+	)
+	return err
+}
+
+// RemoveFeedFromCategory takes a feed out of a category.
+// It reports false if the feed was not in the category.
+func (db *DB) RemoveFeedFromCategory(ctx context.Context, feedID, categoryID int) (bool, error) {
+	affected, err := db.execRowsAffected(
+		ctx,
+		"DELETE FROM feed_categories WHERE feed_id = ? AND category_id = ?",
+		feedID, categoryID,
+	)
+	return affected > 0, err
+}
+
+// GetCategorySummaries returns every category of a user, ordered by name, with
+// its feeds and the number of articles in them the user has not read
+func (db *DB) GetCategorySummaries(ctx context.Context, userID int) ([]CategorySummary, error) {
+	categories, err := db.ListCategories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.summarizeCategories(ctx, userID, categories)
+}
+
+// GetCategorySummary returns the summary of a user's category, or nil if the category does not exist
+func (db *DB) GetCategorySummary(ctx context.Context, userID, id int) (*CategorySummary, error) {
+	category, err := db.GetCategoryByID(ctx, userID, id)
+	if err != nil || category == nil {
+		return nil, err
+	}
+
+	summaries, err := db.summarizeCategories(ctx, userID, []Category{*category})
+	if err != nil {
+		return nil, err
+	}
+
+	return &summaries[0], nil
+}
+
+// summarizeCategories loads the feeds and the user's unread counts of the given categories
+func (db *DB) summarizeCategories(ctx context.Context, userID int, categories []Category) ([]CategorySummary, error) {
+	summaries := make([]CategorySummary, 0, len(categories))
+	if len(categories) == 0 {
+		return summaries, nil
+	}
+
+	index := make(map[int]int, len(categories))
+	args := make([]any, 0, len(categories))
+	for i, category := range categories {
+		summaries = append(summaries, CategorySummary{Category: category, FeedIDs: []int{}})
+		index[category.ID] = i
+		args = append(args, category.ID)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+
+	rows, err := db.conn.QueryContext(
+		ctx,
+		fmt.Sprintf("SELECT category_id, feed_id FROM feed_categories WHERE category_id IN (%s) ORDER BY feed_id", placeholders),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var categoryID, feedID int
+		if err := rows.Scan(&categoryID, &feedID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		summary := &summaries[index[categoryID]]
+		summary.FeedIDs = append(summary.FeedIDs, feedID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.conn.QueryContext(
+		ctx,
+		fmt.Sprintf(`SELECT fc.category_id, COUNT(*)
+		FROM feed_categories fc
+		JOIN articles a ON a.feed_id = fc.feed_id
+		LEFT JOIN user_articles ua ON ua.article_id = a.id AND ua.user_id = ?
+		WHERE COALESCE(ua.is_read, FALSE) = FALSE AND fc.category_id IN (%s)
+		GROUP BY fc.category_id`, placeholders),
+		append([]any{userID}, args...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var categoryID, unread int
+		if err := rows.Scan(&categoryID, &unread); err != nil {
+			return nil, err
+		}
+		summaries[index[categoryID]].UnreadCount = unread
+	}
+
+	return summaries, rows.Err()
+}
+
+// GetFeedCategories returns the categories of a user that contain a feed, ordered by name
+func (db *DB) GetFeedCategories(ctx context.Context, userID, feedID int) ([]Category, error) {
+	rows, err := db.conn.QueryContext(
+		ctx,
+		"SELECT c.id, c.name FROM categories c JOIN feed_categories fc ON fc.category_id = c.id WHERE fc.feed_id = ? AND c.user_id = ? ORDER BY c.name",
+		feedID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []Category
+	for rows.Next() {
+		var category Category
+		if err := rows.Scan(&category.ID, &category.Name); err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
@@ -1,29 +1,56 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
-
-	_ "github.com/mattn/go-sqlite3"
 )
 
-// DB wraps database connection
+// DB wraps database connection. It implements Store for SQLite and PostgreSQL.
 type DB struct {
-	conn *sql.DB
+	conn    *sqlConn
+	dialect *dialect
 }
 
-// New creates a new database connection
+// New creates a new database connection. The backend is chosen by the scheme
+// of the DSN: postgres:// and postgresql:// URLs connect to PostgreSQL, while
+// file paths, optionally prefixed with sqlite:// or sqlite3://, open SQLite.
 func New(dsn string) (*DB, error) {
-	conn, err := sql.Open("sqlite3", dsn)
+	d, dsn, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := sql.Open(d.driver, dsn)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := conn.Ping(); err != nil {
+		conn.Close()
 		return nil, err
 	}
 
-	return &DB{conn: conn}, nil
+	return &DB{conn: &sqlConn{DB: conn, dialect: d}, dialect: d}, nil
+}
+
+// parseDSN picks the dialect of a DSN and returns the DSN to pass to its driver
+func parseDSN(dsn string) (*dialect, string, error) {
+	scheme, rest, found := strings.Cut(dsn, "://")
+	if !found {
+		return sqliteDialect, withDefaultParams(dsn), nil
+	}
+
+	switch strings.ToLower(scheme) {
+	case "postgres", "postgresql":
+		return postgresDialect, dsn, nil
+	case "sqlite", "sqlite3":
+		return sqliteDialect, withDefaultParams(rest), nil
+	default:
+		return nil, "", fmt.Errorf("unsupported database scheme %q", scheme)
+	}
 }
 
 // Close closes the database connection
@@ -31,32 +58,77 @@ func (db *DB) Close() error {
 	return db.conn.Close()
 }
 
+// Driver returns the name of the database/sql driver: "sqlite" or "pgx"
+func (db *DB) Driver() string {
+	return db.dialect.driver
+}
+
+// SQL returns the underlying connection pool for adapters that run their own
+// queries against the migrated schema. Such queries bypass placeholder
+// rebinding, so they must be written for the database returned by Driver.
+func (db *DB) SQL() *sql.DB {
+	return db.conn.DB
+}
+
 // Feed represents a feed in the database
 type Feed struct {
-	ID          int
-	URL         string
-	Title       *string
-	Description *string
+	ID           int
+	URL          string
+	Title        *string
+	Description  *string
+	ETag         *string
+	LastModified *string
+	// Format is the source format, see rss.FeedInfo.Format
+	Format        *string
+	FormatVersion *string
+	Health        FeedHealth
 }
 
+// feedColumns lists the columns scanned by scanFeed
+const feedColumns = "id, url, title, description, etag, last_modified, format, format_version, " +
+	"consecutive_failures, last_http_status, last_error, last_parse_error, " +
+	"last_fetched_at, last_success_at, next_fetch_at, disabled_at"
+
 // Article represents an article in the database
 type Article struct {
-	ID             int
-	FeedID         int
-	Title          string
-	Content        *string
+	ID      int
+	FeedID  int
+	GUID    *string
+	Link    *string
+	Title   string
+	Content *string
+	// RawContent is the content as the feed delivered it, before sanitization
+	RawContent      *string
+	ContentType     *string
+	Summary         *string
 	PublicationDate *time.Time
-	IsRead         bool
+	PublishedAt     *time.Time
+	UpdatedAt       *time.Time
+	Authors         []string
+	ImageURL        *string
+	BannerImage     *string
+	// Extensions is a JSON object with the JSON Feed extension members
+	Extensions *string
+	IsRead     bool
+	IsStarred  bool
+	Categories []string
+	Enclosures []Enclosure
+}
+
+// Enclosure represents media attached to an article
+type Enclosure struct {
+	URL    string
+	Type   *string
+	Length *int64
 }
 
 // GetFeedByURL retrieves a feed by its URL
-func (db *DB) GetFeedByURL(url string) (*Feed, error) {
-	var feed Feed
-	err := db.conn.QueryRow(
-		"SELECT id, url, title, description FROM feeds WHERE url = ?",
+func (db *DB) GetFeedByURL(ctx context.Context, url string) (*Feed, error) {
+	feed, err := scanFeed(db.conn.QueryRowContext(
+		ctx,
+		"SELECT "+feedColumns+" FROM feeds WHERE url = ?",
 		url,
-	).Scan(&feed.ID, &feed.URL, &feed.Title, &feed.Description)
-
+	))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -64,99 +136,255 @@ func (db *DB) GetFeedByURL(url string) (*Feed, error) {
 		return nil, err
 	}
 
-	return &feed, nil
+	return feed, nil
 }
 
 // CreateFeed creates a new feed
-func (db *DB) CreateFeed(url string, title *string, description *string) (*Feed, error) {
-	result, err := db.conn.Exec(
-		"INSERT INTO feeds (url, title, description) VALUES (?, ?, ?)",
+func (db *DB) CreateFeed(ctx context.Context, url string, title *string, description *string) (*Feed, error) {
+	var id int
+	err := db.conn.QueryRowContext(
+		ctx,
+		"INSERT INTO feeds (url, title, description) VALUES (?, ?, ?) RETURNING id",
 		url, title, description,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	id, err := result.LastInsertId()
+	).Scan(&id)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Feed{
-		ID:          int(id),
+		ID:          id,
 		URL:         url,
 		Title:       title,
 		Description: description,
 	}, nil
 }
 
-// CreateArticle creates a new article
-func (db *DB) CreateArticle(feedID int, title string, content *string, publicationDate *time.Time) (*Article, error) {
-	result, err := db.conn.Exec(
-		"INSERT INTO articles (feed_id, title, content, publication_date) VALUES (?, ?, ?, ?)",
-		feedID, title, content, publicationDate,
+// NewArticle holds a parsed item to be stored
+type NewArticle struct {
+	// Key identifies the item within its feed, see rss.Item.Key
+	Key     string
+	GUID    string
+	Link    string
+	Title   string
+	Content string
+	// RawContent is Content before sanitization, see rss.Item.RawContent
+	RawContent string
+	// ContentType is the markup of Content, see rss.Item.ContentType
+	ContentType     string
+	Summary         string
+	PublicationDate *time.Time
+	PublishedAt     *time.Time
+	UpdatedAt       *time.Time
+	Authors         []string
+	ImageURL        string
+	BannerImage     string
+	// Extensions is a JSON object with the JSON Feed extension members, empty if there are none
+	Extensions string
+	Categories []string
+	Enclosures []Enclosure
+}
+
+// GetArticlesByFeedID retrieves all articles for a feed with the reading state of a user
+func (db *DB) GetArticlesByFeedID(ctx context.Context, userID, feedID int) ([]Article, error) {
+	return db.queryArticles(
+		ctx,
+		"SELECT "+articleColumns+" FROM "+articleSource+" WHERE a.feed_id = ? ORDER BY a.publication_date DESC NULLS LAST",
+		userID, feedID,
 	)
+}
+
+// GetFeedByID retrieves a feed by its ID
+func (db *DB) GetFeedByID(ctx context.Context, id int) (*Feed, error) {
+	feed, err := scanFeed(db.conn.QueryRowContext(
+		ctx,
+		"SELECT "+feedColumns+" FROM feeds WHERE id = ?",
+		id,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	id, err := result.LastInsertId()
+	return feed, nil
+}
+
+// GetUserFeed retrieves a feed a user subscribes to, or nil if the user does not subscribe to it
+func (db *DB) GetUserFeed(ctx context.Context, userID, feedID int) (*Feed, error) {
+	feed, err := scanFeed(db.conn.QueryRowContext(
+		ctx,
+		"SELECT "+feedColumns+" FROM feeds WHERE id = ? AND id IN (SELECT feed_id FROM user_feeds WHERE user_id = ?)",
+		feedID, userID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &Article{
-		ID:             int(id),
-		FeedID:         feedID,
-		Title:          title,
-		Content:        content,
-		PublicationDate: publicationDate,
-		IsRead:         false,
-	}, nil
+	return feed, nil
+}
+
+// ListFeeds retrieves a page of the feeds a user subscribes to, ordered by ID
+func (db *DB) ListFeeds(ctx context.Context, userID, limit, offset int) ([]Feed, error) {
+	rows, err := db.conn.QueryContext(
+		ctx,
+		"SELECT "+feedColumns+" FROM feeds WHERE id IN (SELECT feed_id FROM user_feeds WHERE user_id = ?) ORDER BY id LIMIT ? OFFSET ?",
+		userID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFeeds(rows)
 }
 
-// GetArticlesByFeedID retrieves all articles for a feed
-func (db *DB) GetArticlesByFeedID(feedID int) ([]Article, error) {
-	rows, err := db.conn.Query(
-		"SELECT id, feed_id, title, content, publication_date, is_read FROM articles WHERE feed_id = ? ORDER BY publication_date DESC",
-		feedID,
+// ListAllFeeds retrieves every feed a user subscribes to, ordered by ID
+func (db *DB) ListAllFeeds(ctx context.Context, userID int) ([]Feed, error) {
+	rows, err := db.conn.QueryContext(
+		ctx,
+		"SELECT "+feedColumns+" FROM feeds WHERE id IN (SELECT feed_id FROM user_feeds WHERE user_id = ?) ORDER BY id",
+		userID,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var articles []Article
+	return scanFeeds(rows)
+}
+
+// scanFeed reads a single row selected with feedColumns
+func scanFeed(row interface{ Scan(dest ...any) error }) (*Feed, error) {
+	var feed Feed
+	health := &feed.Health
+	err := row.Scan(
+		&feed.ID, &feed.URL, &feed.Title, &feed.Description, &feed.ETag, &feed.LastModified, &feed.Format, &feed.FormatVersion,
+		&health.ConsecutiveFailures, &health.LastHTTPStatus, &health.LastError, &health.LastParseError,
+		&health.LastFetchedAt, &health.LastSuccessAt, &health.NextFetchAt, &health.DisabledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	health.FeedID = feed.ID
+
+	return &feed, nil
+}
+
+// scanFeeds reads all rows selected with feedColumns
+func scanFeeds(rows *sql.Rows) ([]Feed, error) {
+	var feeds []Feed
 	for rows.Next() {
-		var article Article
-		err := rows.Scan(
-			&article.ID,
-			&article.FeedID,
-			&article.Title,
-			&article.Content,
-			&article.PublicationDate,
-			&article.IsRead,
-		)
+		feed, err := scanFeed(rows)
 		if err != nil {
 			return nil, err
 		}
-		articles = append(articles, article)
+		feeds = append(feeds, *feed)
 	}
 
-	return articles, rows.Err()
+	return feeds, rows.Err()
 }
 
-// ArticleExists checks if an article with the same title and feed_id already exists
-func (db *DB) ArticleExists(feedID int, title string) (bool, error) {
+// CountFeeds returns the number of feeds a user subscribes to
+func (db *DB) CountFeeds(ctx context.Context, userID int) (int, error) {
 	var count int
-	err := db.conn.QueryRow(
-		"SELECT COUNT(*) FROM articles WHERE feed_id = ? AND title = ?",
-		feedID, title,
-	).Scan(&count)
+	err := db.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM user_feeds WHERE user_id = ?", userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetRecentArticlesByFeedID retrieves the latest articles for a feed with the reading state of a user
+func (db *DB) GetRecentArticlesByFeedID(ctx context.Context, userID, feedID, limit int) ([]Article, error) {
+	return db.queryArticles(
+		ctx,
+		"SELECT "+articleColumns+" FROM "+articleSource+" WHERE a.feed_id = ? ORDER BY a.publication_date DESC NULLS LAST, a.id DESC LIMIT ?",
+		userID, feedID, limit,
+	)
+}
+
+// SubscribeFeed subscribes a user to a feed. It reports false if the user already subscribes to it.
+func (db *DB) SubscribeFeed(ctx context.Context, userID, feedID int) (bool, error) {
+	affected, err := db.execRowsAffected(
+		ctx,
+		"INSERT INTO user_feeds (user_id, feed_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+		userID, feedID,
+	)
+	return affected > 0, err
+}
+
+// UnsubscribeFeed removes a user's subscription to a feed along with the user's
+// category links, reading state and webhooks limited to it. The feed itself is deleted with its
+// articles once nobody subscribes to it. It reports false if the user does not
+// subscribe to the feed.
+func (db *DB) UnsubscribeFeed(ctx context.Context, userID, feedID int) (bool, error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
 	if err != nil {
 		return false, err
 	}
+	defer tx.Rollback()
 
-	return count > 0, nil
+	result, err := tx.ExecContext(ctx, "DELETE FROM user_feeds WHERE user_id = ? AND feed_id = ?", userID, feedID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	// Webhooks limited to the feed would receive nothing anymore
+	if _, err := deleteWebhooks(ctx, tx, "feed_id = ? AND user_id = ?", feedID, userID); err != nil {
+		return false, err
+	}
+
+	for _, query := range []string{
+		"DELETE FROM feed_categories WHERE feed_id = ? AND category_id IN (SELECT id FROM categories WHERE user_id = ?)",
+		"DELETE FROM user_articles WHERE article_id IN (SELECT id FROM articles WHERE feed_id = ?) AND user_id = ?",
+	} {
+		if _, err := tx.ExecContext(ctx, query, feedID, userID); err != nil {
+			return false, err
+		}
+	}
+
+	var subscribers int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM user_feeds WHERE feed_id = ?", feedID).Scan(&subscribers); err != nil {
+		return false, err
+	}
+	if subscribers == 0 {
+		if err := deleteFeed(ctx, tx, feedID); err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit()
 }
 
+// deleteFeed deletes a feed together with its articles, category and user
+// links, and detaches it from the jobs that subscribed users to it
+func deleteFeed(ctx context.Context, tx *sqlTx, id int) error {
+	for _, query := range []string{
+		"DELETE FROM article_enclosures WHERE article_id IN (SELECT id FROM articles WHERE feed_id = ?)",
+		"DELETE FROM article_tags WHERE article_id IN (SELECT id FROM articles WHERE feed_id = ?)",
+		"DELETE FROM user_articles WHERE article_id IN (SELECT id FROM articles WHERE feed_id = ?)",
+		"DELETE FROM articles WHERE feed_id = ?",
+		"DELETE FROM feed_categories WHERE feed_id = ?",
+		"DELETE FROM user_feeds WHERE feed_id = ?",
+		"UPDATE feed_jobs SET feed_id = NULL WHERE feed_id = ?",
+		"DELETE FROM feeds WHERE id = ?",
+	} {
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
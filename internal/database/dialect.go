@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+)
+
+// dialect describes what differs between the supported databases. Queries are
+// written once with ? placeholders and portable SQL; everything else lives here.
+type dialect struct {
+	// driver is the database/sql driver name
+	driver string
+	// numberedPlaceholders rewrites ? into $1, $2, ...
+	numberedPlaceholders bool
+	// migrations holds the schema migrations of the database
+	migrations fs.FS
+	// goose is the dialect goose applies the migrations with
+	goose goose.Dialect
+	// migrationLock makes servers starting together wait for each other's migrations
+	migrationLock bool
+	// lockUsers, run first in a transaction, makes concurrent registrations
+	// wait for each other, so only one of them can be the first user
+	lockUsers string
+	// search describes full-text search over articles
+	search searchDialect
+}
+
+// searchDialect is the full-text search part of a dialect
+type searchDialect struct {
+	// source joins articleSource with the index; it binds the user, then the query
+	source string
+	// match restricts source to the articles matching the query
+	match string
+	// columns selects the highlighted title, the content snippet and a score
+	// named score, lower scores being more relevant
+	columns string
+	// query turns user input into the query language of the index
+	query func(input string) string
+}
+
+// rebind rewrites the ? placeholders of a query for the dialect
+func (d *dialect) rebind(query string) string {
+	if !d.numberedPlaceholders {
+		return query
+	}
+
+	var (
+		b        strings.Builder
+		n        int
+		inString bool
+	)
+	b.Grow(len(query) + 16)
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			inString = !inString
+		case r == '?' && !inString:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// sqlConn is a connection pool that rebinds queries for its dialect
+type sqlConn struct {
+	*sql.DB
+	dialect *dialect
+}
+
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return c.DB.ExecContext(ctx, c.dialect.rebind(query), args...)
+}
+
+func (c *sqlConn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return c.DB.QueryContext(ctx, c.dialect.rebind(query), args...)
+}
+
+func (c *sqlConn) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return c.DB.QueryRowContext(ctx, c.dialect.rebind(query), args...)
+}
+
+func (c *sqlConn) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sqlTx, error) {
+	tx, err := c.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlTx{Tx: tx, dialect: c.dialect}, nil
+}
+
+// sqlTx is a transaction that rebinds queries for its dialect
+type sqlTx struct {
+	*sql.Tx
+	dialect *dialect
+}
+
+func (tx *sqlTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return tx.Tx.ExecContext(ctx, tx.dialect.rebind(query), args...)
+}
+
+func (tx *sqlTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, tx.dialect.rebind(query), args...)
+}
+
+func (tx *sqlTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRowContext(ctx, tx.dialect.rebind(query), args...)
+}
+
+func (tx *sqlTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return tx.Tx.PrepareContext(ctx, tx.dialect.rebind(query))
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+// FeedHealth is the outcome of the recent fetches of a feed
+type FeedHealth struct {
+	FeedID int
+	// ConsecutiveFailures counts the failed fetches since the last success
+	ConsecutiveFailures int
+	// LastHTTPStatus is the status of the last response, nil if the server did not answer
+	LastHTTPStatus *int
+	// LastError is the error of the last fetch, nil if it succeeded
+	LastError *string
+	// LastParseError is set when the last fetch got a document that is not a valid feed
+	LastParseError *string
+	LastFetchedAt  *time.Time
+	LastSuccessAt  *time.Time
+	NextFetchAt    *time.Time
+	// DisabledAt is when the feed was disabled after too many failures, nil if enabled
+	DisabledAt *time.Time
+}
+
+// GetDueFeeds retrieves enabled feeds whose next fetch time has come, most
+// overdue first. Feeds that were never fetched by the scheduler are always due.
+func (db *DB) GetDueFeeds(ctx context.Context, now time.Time, limit int) ([]Feed, error) {
+	rows, err := db.conn.QueryContext(
+		ctx,
+		"SELECT "+feedColumns+" FROM feeds WHERE disabled_at IS NULL AND (next_fetch_at IS NULL OR next_fetch_at <= ?) "+
+			"ORDER BY next_fetch_at NULLS FIRST, id LIMIT ?",
+		now.UTC(), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFeeds(rows)
+}
+
+// UpdateFeedHealth stores the health of a feed, including when it is due next
+func (db *DB) UpdateFeedHealth(ctx context.Context, health FeedHealth) error {
+	_, err := db.conn.ExecContext(
+		ctx,
+		`UPDATE feeds SET consecutive_failures = ?, last_http_status = ?, last_error = ?, last_parse_error = ?,
+			last_fetched_at = ?, last_success_at = ?, next_fetch_at = ?, disabled_at = ?
+		WHERE id = ?`,
+		health.ConsecutiveFailures, health.LastHTTPStatus, health.LastError, health.LastParseError,
+		utcTime(health.LastFetchedAt), utcTime(health.LastSuccessAt), utcTime(health.NextFetchAt),
+		utcTime(health.DisabledAt), health.FeedID,
+	)
+	return err
+}
+
+// EnableFeed enables a disabled feed again and makes it due at once. The
+// failure count starts over; the rest of the health is kept for reference.
+// It reports whether the feed was disabled.
+func (db *DB) EnableFeed(ctx context.Context, feedID int) (bool, error) {
+	result, err := db.conn.ExecContext(
+		ctx,
+		"UPDATE feeds SET disabled_at = NULL, consecutive_failures = 0, next_fetch_at = NULL WHERE id = ? AND disabled_at IS NOT NULL",
+		feedID,
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// utcTime returns a copy of t in UTC, or nil
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// UpdateFeedCacheValidators stores the HTTP validators to send with the next fetch
+func (db *DB) UpdateFeedCacheValidators(ctx context.Context, feedID int, etag, lastModified string) error {
+	_, err := db.conn.ExecContext(
+		ctx,
+		"UPDATE feeds SET etag = NULLIF(?, ''), last_modified = NULLIF(?, '') WHERE id = ?",
+		etag, lastModified, feedID,
+	)
+	return err
+}
+
+// UpdateFeedFormat records the source format of a feed and its version
+func (db *DB) UpdateFeedFormat(ctx context.Context, feedID int, format, version string) error {
+	_, err := db.conn.ExecContext(
+		ctx,
+		"UPDATE feeds SET format = NULLIF(?, ''), format_version = NULLIF(?, '') WHERE id = ?",
+		format, version, feedID,
+	)
+	return err
+}
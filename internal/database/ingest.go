@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// IngestResult summarizes how the items of a feed were stored
+type IngestResult struct {
+	Inserted int
+	Updated  int
+	// Skipped counts items that were stored already and have not changed,
+	// as well as items that failed
+	Skipped int
+	// Errors lists the items that could not be stored
+	Errors []ItemError
+	// Deliveries counts the webhook deliveries queued for inserted articles
+	Deliveries int
+}
+
+// ItemError reports an item that could not be stored
+type ItemError struct {
+	Key string
+	Err error
+}
+
+func (e ItemError) Error() string {
+	return fmt.Sprintf("item %s: %v", e.Key, e.Err)
+}
+
+func (e ItemError) Unwrap() error { return e.Err }
+
+// ingestStatements are the prepared statements IngestFeed runs per item
+type ingestStatements struct {
+	adopt  *sql.Stmt
+	lookup *sql.Stmt
+	upsert *sql.Stmt
+}
+
+// IngestFeed stores the items of a feed in one transaction. Items are keyed by
+// (feed_id, guid_or_hash): a new key inserts an article, a known one updates it
+// in place, keeping its ID and read state, unless its stored fields are unchanged.
+// An item that fails is rolled back on its own and reported in the result; the
+// returned error is set only when the transaction as a whole fails.
+// Every inserted article is queued for the webhooks of the feed in the same
+// transaction, so no new article is stored without its deliveries.
+func (db *DB) IngestFeed(ctx context.Context, feedID int, items []NewArticle) (IngestResult, error) {
+	var result IngestResult
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	statements, err := prepareIngestStatements(ctx, tx)
+	if err != nil {
+		return result, err
+	}
+	defer statements.close()
+
+	webhookIDs, err := feedWebhooks(ctx, tx, feedID)
+	if err != nil {
+		return result, err
+	}
+
+	for _, item := range items {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT ingest_item"); err != nil {
+			return IngestResult{}, err
+		}
+
+		inserted, changed, err := ingestItem(ctx, tx, statements, feedID, item, webhookIDs)
+		if err != nil {
+			if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO ingest_item"); rollbackErr != nil {
+				return IngestResult{}, rollbackErr
+			}
+			result.Skipped++
+			result.Errors = append(result.Errors, ItemError{Key: item.Key, Err: err})
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE ingest_item"); err != nil {
+			return IngestResult{}, err
+		}
+
+		switch {
+		case inserted:
+			result.Inserted++
+			result.Deliveries += len(webhookIDs)
+		case changed:
+			result.Updated++
+		default:
+			result.Skipped++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return IngestResult{}, err
+	}
+
+	return result, nil
+}
+
+// prepareIngestStatements prepares the per-item statements of IngestFeed
+func prepareIngestStatements(ctx context.Context, tx *sqlTx) (*ingestStatements, error) {
+	var statements ingestStatements
+	for _, prepare := range []struct {
+		stmt  **sql.Stmt
+		query string
+	}{
+		// Articles stored before keys existed are matched once by title,
+		// unless another article already has the key
+		{&statements.adopt, `UPDATE articles SET guid_or_hash = ?
+		WHERE id = (SELECT id FROM articles WHERE feed_id = ? AND guid_or_hash IS NULL AND title = ? LIMIT 1)
+			AND NOT EXISTS (SELECT 1 FROM articles WHERE feed_id = ? AND guid_or_hash = ?)`},
+		{&statements.lookup, "SELECT id FROM articles WHERE feed_id = ? AND guid_or_hash = ?"},
+		// Returns no row when the stored article is identical
+		{&statements.upsert, `INSERT INTO articles (feed_id, guid_or_hash, guid, link, title, content, raw_content, content_type, summary,
+			publication_date, published_at, updated_at, authors, image_url, banner_image, extensions)
+		VALUES (?, ?, NULLIF(?, ''), NULLIF(?, ''), ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''),
+			?, ?, ?, NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''))
+		ON CONFLICT (feed_id, guid_or_hash) DO UPDATE SET
+			guid = excluded.guid,
+			link = excluded.link,
+			title = excluded.title,
+			content = excluded.content,
+			raw_content = excluded.raw_content,
+			content_type = excluded.content_type,
+			summary = excluded.summary,
+			publication_date = excluded.publication_date,
+			published_at = excluded.published_at,
+			updated_at = excluded.updated_at,
+			authors = excluded.authors,
+			image_url = excluded.image_url,
+			banner_image = excluded.banner_image,
+			extensions = excluded.extensions
+		WHERE articles.guid IS DISTINCT FROM excluded.guid
+			OR articles.link IS DISTINCT FROM excluded.link
+			OR articles.title IS DISTINCT FROM excluded.title
+			OR articles.content IS DISTINCT FROM excluded.content
+			OR articles.raw_content IS DISTINCT FROM excluded.raw_content
+			OR articles.content_type IS DISTINCT FROM excluded.content_type
+			OR articles.summary IS DISTINCT FROM excluded.summary
+			OR articles.publication_date IS DISTINCT FROM excluded.publication_date
+			OR articles.published_at IS DISTINCT FROM excluded.published_at
+			OR articles.updated_at IS DISTINCT FROM excluded.updated_at
+			OR articles.authors IS DISTINCT FROM excluded.authors
+			OR articles.image_url IS DISTINCT FROM excluded.image_url
+			OR articles.banner_image IS DISTINCT FROM excluded.banner_image
+			OR articles.extensions IS DISTINCT FROM excluded.extensions
+		RETURNING id`},
+	} {
+		stmt, err := tx.PrepareContext(ctx, prepare.query)
+		if err != nil {
+			statements.close()
+			return nil, err
+		}
+		*prepare.stmt = stmt
+	}
+
+	return &statements, nil
+}
+
+// close releases the prepared statements
+func (s *ingestStatements) close() {
+	for _, stmt := range []*sql.Stmt{s.adopt, s.lookup, s.upsert} {
+		if stmt != nil {
+			stmt.Close()
+		}
+	}
+}
+
+// ingestItem upserts a single item and replaces its categories and enclosures
+// if it was inserted or changed. An unchanged item is left untouched. An
+// inserted item is queued for delivery to the webhooks.
+func ingestItem(ctx context.Context, tx *sqlTx, statements *ingestStatements, feedID int, item NewArticle, webhookIDs []int) (inserted, changed bool, err error) {
+	if _, err := statements.adopt.ExecContext(ctx, item.Key, feedID, item.Title, feedID, item.Key); err != nil {
+		return false, false, err
+	}
+
+	var existingID int
+	err = statements.lookup.QueryRowContext(ctx, feedID, item.Key).Scan(&existingID)
+	if err != nil && err != sql.ErrNoRows {
+		return false, false, err
+	}
+	inserted = err == sql.ErrNoRows
+
+	var articleID int
+	err = statements.upsert.QueryRowContext(
+		ctx,
+		feedID, item.Key, item.GUID, item.Link, item.Title, item.Content, item.RawContent, item.ContentType, item.Summary,
+		item.PublicationDate, item.PublishedAt, item.UpdatedAt, joinAuthors(item.Authors), item.ImageURL,
+		item.BannerImage, item.Extensions,
+	).Scan(&articleID)
+	if err == sql.ErrNoRows {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+
+	if err := replaceArticleMetadata(ctx, tx, articleID, item.Categories, item.Enclosures); err != nil {
+		return false, false, err
+	}
+	if inserted {
+		if err := queueArticleDeliveries(ctx, tx, webhookIDs, feedID, articleID, item); err != nil {
+			return false, false, err
+		}
+	}
+
+	return inserted, !inserted, nil
+}
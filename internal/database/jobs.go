@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Feed job states
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// FeedJob is a queued request of a user to subscribe to a feed
+type FeedJob struct {
+	ID     int
+	UserID int
+	URL    string
+	// State is one of JobQueued, JobRunning, JobSucceeded and JobFailed
+	State string
+	// Error explains why a failed job failed
+	Error *string
+	// FeedID is the feed a succeeded job subscribed the user to
+	FeedID     *int
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// feedJobColumns lists the columns scanned by scanFeedJob
+const feedJobColumns = "id, user_id, url, state, error, feed_id, created_at, started_at, finished_at"
+
+// scanFeedJob reads a feed job row, returning nil if there is none
+func scanFeedJob(row *sql.Row) (*FeedJob, error) {
+	var job FeedJob
+	err := row.Scan(
+		&job.ID, &job.UserID, &job.URL, &job.State, &job.Error, &job.FeedID,
+		&job.CreatedAt, &job.StartedAt, &job.FinishedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// CreateFeedJob queues a job subscribing a user to the feed at url
+func (db *DB) CreateFeedJob(ctx context.Context, userID int, url string) (*FeedJob, error) {
+	return scanFeedJob(db.conn.QueryRowContext(
+		ctx,
+		"INSERT INTO feed_jobs (user_id, url, state, created_at) VALUES (?, ?, ?, ?) RETURNING "+feedJobColumns,
+		userID, url, JobQueued, time.Now().UTC(),
+	))
+}
+
+// GetFeedJob retrieves a job of a user, or nil if the user has no such job
+func (db *DB) GetFeedJob(ctx context.Context, userID, id int) (*FeedJob, error) {
+	return scanFeedJob(db.conn.QueryRowContext(
+		ctx,
+		"SELECT "+feedJobColumns+" FROM feed_jobs WHERE id = ? AND user_id = ?",
+		id, userID,
+	))
+}
+
+// ClaimFeedJob marks the oldest queued job as running and returns it, or nil
+// if the queue is empty. A job is claimed by one caller only.
+func (db *DB) ClaimFeedJob(ctx context.Context) (*FeedJob, error) {
+	return scanFeedJob(db.conn.QueryRowContext(
+		ctx,
+		`UPDATE feed_jobs SET state = ?, started_at = ?
+		WHERE id = (SELECT id FROM feed_jobs WHERE state = ? ORDER BY id LIMIT 1) AND state = ?
+		RETURNING `+feedJobColumns,
+		JobRunning, time.Now().UTC(), JobQueued, JobQueued,
+	))
+}
+
+// FinishFeedJob records the outcome of a running job: the subscribed feed,
+// or the error that made it fail when jobErr is not nil
+func (db *DB) FinishFeedJob(ctx context.Context, id int, feedID *int, jobErr *string) error {
+	state := JobSucceeded
+	if jobErr != nil {
+		state = JobFailed
+	}
+
+	_, err := db.conn.ExecContext(
+		ctx,
+		"UPDATE feed_jobs SET state = ?, error = ?, feed_id = ?, finished_at = ? WHERE id = ?",
+		state, jobErr, feedID, time.Now().UTC(), id,
+	)
+	return err
+}
+
+// RequeueRunningFeedJobs puts the jobs left running by a stopped server back
+// in the queue and returns how many there were
+func (db *DB) RequeueRunningFeedJobs(ctx context.Context) (int64, error) {
+	result, err := db.conn.ExecContext(
+		ctx,
+		"UPDATE feed_jobs SET state = ?, started_at = NULL WHERE state = ?",
+		JobQueued, JobRunning,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// Migration is a schema change read from the migrations of the dialect
+type Migration struct {
+	Version int64
+	Name    string
+}
+
+// MigrationStatus reports when a migration was applied; AppliedAt is nil for
+// a pending migration
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrate applies the pending migrations in version order, each in its own
+// transaction, and returns the ones it applied
+func (db *DB) Migrate(ctx context.Context) ([]Migration, error) {
+	provider, err := db.migrationProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := provider.Up(ctx)
+	var partial *goose.PartialError
+	if errors.As(err, &partial) {
+		results = partial.Applied
+	}
+	done := make([]Migration, 0, len(results))
+	for _, result := range results {
+		done = append(done, toMigration(result.Source))
+	}
+	return done, err
+}
+
+// MigrateDown rolls back the latest applied migration and returns it,
+// or nil if no migration is applied
+func (db *DB) MigrateDown(ctx context.Context) (*Migration, error) {
+	provider, err := db.migrationProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := provider.GetDBVersion(ctx)
+	if err != nil || version == 0 {
+		return nil, err
+	}
+
+	result, err := provider.Down(ctx)
+	if err != nil {
+		return nil, err
+	}
+	migration := toMigration(result.Source)
+	return &migration, nil
+}
+
+// MigrationStatus lists all known migrations in version order
+func (db *DB) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	provider, err := db.migrationProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(found))
+	for _, status := range found {
+		converted := MigrationStatus{Migration: toMigration(status.Source)}
+		if status.State == goose.StateApplied {
+			appliedAt := status.AppliedAt
+			converted.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, converted)
+	}
+
+	return statuses, nil
+}
+
+// migrationProvider runs the migrations of the dialect with goose, in the
+// goose_db_version table, so databases migrated with the goose CLI are
+// picked up where they left off
+func (db *DB) migrationProvider() (*goose.Provider, error) {
+	options := []goose.ProviderOption{goose.WithDisableGlobalRegistry(true)}
+	if db.dialect.migrationLock {
+		// Servers starting together apply the migrations once
+		locker, err := lock.NewPostgresSessionLocker()
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, goose.WithSessionLocker(locker))
+	}
+
+	provider, err := goose.NewProvider(db.dialect.goose, db.conn.DB, db.dialect.migrations, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return provider, nil
+}
+
+// toMigration describes a migration by the file it was read from
+func toMigration(source *goose.Source) Migration {
+	_, name, _ := strings.Cut(strings.TrimSuffix(path.Base(source.Path), ".sql"), "_")
+	return Migration{Version: source.Version, Name: name}
+}
+
+// String returns the file name the migration was read from
+func (m Migration) String() string {
+	return fmt.Sprintf("%d_%s.sql", m.Version, m.Name)
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"rss-aggregator/migrations"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// postgresDialect stores data in PostgreSQL and searches it with a tsvector
+// column kept up to date by the database
+var postgresDialect = &dialect{
+	driver:               "pgx",
+	numberedPlaceholders: true,
+	migrations:           mustSub(migrations.Postgres, "postgres"),
+	goose:                goose.DialectPostgres,
+	migrationLock:        true,
+	// Conflicts with itself but not with reads
+	lockUsers: "LOCK TABLE users IN SHARE ROW EXCLUSIVE MODE",
+	search: searchDialect{
+		source: articleSource + ", to_tsquery('simple', ?) AS query",
+		match:  "a.search @@ query",
+		// Title lexemes carry weight A and content lexemes weight B, so title
+		// matches rank higher; ts_rank grows with relevance, hence the negation
+		columns: fmt.Sprintf(
+			`ts_headline('simple', a.title, query, 'StartSel=%s, StopSel=%s, HighlightAll=true'),
+			ts_headline('simple', COALESCE(a.content, ''), query, 'StartSel=%s, StopSel=%s, MaxWords=%d, MinWords=%d'),
+			-ts_rank(a.search, query) AS score`,
+			HighlightStart, HighlightEnd, HighlightStart, HighlightEnd, snippetTokens, snippetTokens/2,
+		),
+		query: tsQuery,
+	},
+}
+
+// tsQuery turns user input into a tsquery that matches every term literally,
+// so quotes and operators in the input cannot cause syntax errors
+func tsQuery(input string) string {
+	var terms []string
+	for _, term := range strings.Fields(input) {
+		prefix := strings.HasSuffix(term, "*")
+		term = strings.TrimRight(term, "*")
+		if term == "" {
+			continue
+		}
+
+		quoted := "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(term) + "'"
+		if prefix {
+			quoted += ":*"
+		}
+		terms = append(terms, quoted)
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+// mustSub returns the subtree of an embedded file system
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Markers around matched terms in SearchResult highlights and snippets
+const (
+	HighlightStart = "<mark>"
+	HighlightEnd   = "</mark>"
+)
+
+// snippetTokens is the approximate length of a content snippet in tokens
+const snippetTokens = 24
+
+// SearchFilter describes a full-text query over article titles and content
+type SearchFilter struct {
+	// UserID selects whose subscriptions and reading state are used
+	UserID  int
+	Query   string
+	FeedIDs []int
+	IsRead  *bool
+	Limit   int
+	Offset  int
+}
+
+// SearchResult is an article matching a search query
+type SearchResult struct {
+	Article
+	// TitleHighlight is the title with matched terms wrapped in highlight markers
+	TitleHighlight string
+	// Snippet is the content fragment around the best match
+	Snippet string
+	// Rank scores the match; lower is more relevant
+	Rank float64
+}
+
+// SearchArticles retrieves a page of articles matching the query, most relevant
+// first, together with the total number of matches. Each whitespace-separated
+// term must match; a trailing '*' makes a term match as a prefix.
+func (db *DB) SearchArticles(ctx context.Context, filter SearchFilter) ([]SearchResult, int, error) {
+	search := db.dialect.search
+	match := search.query(filter.Query)
+	if match == "" {
+		return nil, 0, nil
+	}
+
+	conditions := []string{search.match, subscribedArticles}
+	args := []any{filter.UserID, match, filter.UserID}
+	if len(filter.FeedIDs) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(filter.FeedIDs)), ", ")
+		conditions = append(conditions, fmt.Sprintf("a.feed_id IN (%s)", placeholders))
+		for _, id := range filter.FeedIDs {
+			args = append(args, id)
+		}
+	}
+	if filter.IsRead != nil {
+		conditions = append(conditions, "COALESCE(ua.is_read, FALSE) = ?")
+		args = append(args, *filter.IsRead)
+	}
+	from := " FROM " + search.source + " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := db.conn.QueryRowContext(ctx, "SELECT COUNT(*)"+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return nil, 0, nil
+	}
+
+	query := "SELECT " + articleColumns + ", " + search.columns + from + " ORDER BY score, a.id DESC LIMIT ? OFFSET ?"
+	rows, err := db.conn.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var results []SearchResult
+	for rows.Next() {
+		var (
+			result  SearchResult
+			snippet *string
+		)
+		result.Article, err = scanArticle(rows, &result.TitleHighlight, &snippet, &result.Rank)
+		if err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		if snippet != nil {
+			result.Snippet = *snippet
+		}
+		results = append(results, result)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	articles := make([]Article, len(results))
+	for i := range results {
+		articles[i] = results[i].Article
+	}
+	if err := db.loadArticleMetadata(ctx, articles); err != nil {
+		return nil, 0, err
+	}
+	for i := range results {
+		results[i].Article = articles[i]
+	}
+
+	return results, total, nil
+}
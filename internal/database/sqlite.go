@@ -0,0 +1,75 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"rss-aggregator/migrations"
+
+	"github.com/pressly/goose/v3"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteDialect stores data in a SQLite file and searches it with FTS5
+var sqliteDialect = &dialect{
+	driver:     "sqlite",
+	migrations: migrations.SQLite,
+	goose:      goose.DialectSQLite3,
+	// A write starts the write transaction at once; reading first would let
+	// two registrations deadlock when both try to write
+	lockUsers: "UPDATE users SET id = id WHERE FALSE",
+	search: searchDialect{
+		source: "articles_fts JOIN " + articleSource,
+		match:  "a.id = articles_fts.rowid AND articles_fts MATCH ?",
+		// Title matches weigh more than content matches
+		columns: fmt.Sprintf(
+			`highlight(articles_fts, 0, '%s', '%s'),
+			snippet(articles_fts, 1, '%s', '%s', '…', %d),
+			bm25(articles_fts, 10.0, 1.0) AS score`,
+			HighlightStart, HighlightEnd, HighlightStart, HighlightEnd, snippetTokens,
+		),
+		query: ftsQuery,
+	},
+}
+
+// withDefaultParams adds connection parameters the queries rely on unless the
+// DSN sets them: a busy timeout so concurrent writers wait instead of failing,
+// and the textual time format that keeps DATETIME columns comparable as strings
+func withDefaultParams(dsn string) string {
+	path, query, _ := strings.Cut(dsn, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return dsn
+	}
+
+	if !strings.Contains(query, "busy_timeout") {
+		params.Add("_pragma", "busy_timeout(5000)")
+	}
+	if params.Get("_time_format") == "" {
+		params.Set("_time_format", "sqlite")
+	}
+
+	return path + "?" + params.Encode()
+}
+
+// ftsQuery turns user input into an FTS5 query that matches every term
+// literally, so quotes and operators in the input cannot cause syntax errors
+func ftsQuery(input string) string {
+	var terms []string
+	for _, term := range strings.Fields(input) {
+		prefix := strings.HasSuffix(term, "*")
+		term = strings.TrimRight(term, "*")
+		if term == "" {
+			continue
+		}
+
+		quoted := `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		if prefix {
+			quoted += "*"
+		}
+		terms = append(terms, quoted)
+	}
+
+	return strings.Join(terms, " ")
+}
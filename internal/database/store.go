@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+// Store is the storage used by the HTTP adapter and the scheduler. DB implements it
+// on top of SQLite or PostgreSQL.
+type Store interface {
+	// Feeds and subscriptions
+	GetFeedByID(ctx context.Context, id int) (*Feed, error)
+	GetFeedByURL(ctx context.Context, url string) (*Feed, error)
+	CreateFeed(ctx context.Context, url string, title *string, description *string) (*Feed, error)
+	GetUserFeed(ctx context.Context, userID, feedID int) (*Feed, error)
+	ListFeeds(ctx context.Context, userID, limit, offset int) ([]Feed, error)
+	ListAllFeeds(ctx context.Context, userID int) ([]Feed, error)
+	CountFeeds(ctx context.Context, userID int) (int, error)
+	SubscribeFeed(ctx context.Context, userID, feedID int) (bool, error)
+	UnsubscribeFeed(ctx context.Context, userID, feedID int) (bool, error)
+
+	// Background refresh
+	GetDueFeeds(ctx context.Context, now time.Time, limit int) ([]Feed, error)
+	UpdateFeedHealth(ctx context.Context, health FeedHealth) error
+	EnableFeed(ctx context.Context, feedID int) (bool, error)
+	UpdateFeedCacheValidators(ctx context.Context, feedID int, etag, lastModified string) error
+	UpdateFeedFormat(ctx context.Context, feedID int, format, version string) error
+	IngestFeed(ctx context.Context, feedID int, items []NewArticle) (IngestResult, error)
+
+	// Asynchronous subscriptions
+	CreateFeedJob(ctx context.Context, userID int, url string) (*FeedJob, error)
+	GetFeedJob(ctx context.Context, userID, id int) (*FeedJob, error)
+	ClaimFeedJob(ctx context.Context) (*FeedJob, error)
+	FinishFeedJob(ctx context.Context, id int, feedID *int, jobErr *string) error
+	RequeueRunningFeedJobs(ctx context.Context) (int64, error)
+
+	// Webhooks and their outbox
+	CreateWebhook(ctx context.Context, userID int, url, secret string, feedID, categoryID *int) (*Webhook, error)
+	GetWebhook(ctx context.Context, userID, id int) (*Webhook, error)
+	GetWebhookByID(ctx context.Context, id int) (*Webhook, error)
+	ListWebhooks(ctx context.Context, userID int) ([]Webhook, error)
+	DeleteWebhook(ctx context.Context, userID, id int) (bool, error)
+	ListWebhookDeliveries(ctx context.Context, webhookID, limit, offset int) ([]WebhookDelivery, error)
+	CountWebhookDeliveries(ctx context.Context, webhookID int) (int, error)
+	ClaimWebhookDelivery(ctx context.Context, now time.Time) (*WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error
+	RequeueSendingWebhookDeliveries(ctx context.Context) (int64, error)
+
+	// Articles and reading state
+	ListArticles(ctx context.Context, filter ArticleFilter) ([]Article, *ArticleCursor, error)
+	ListNewArticles(ctx context.Context, filter NewArticleFilter) ([]Article, error)
+	LatestArticleID(ctx context.Context) (int, error)
+	GetArticleByID(ctx context.Context, userID, id int) (*Article, error)
+	GetArticlesByFeedID(ctx context.Context, userID, feedID int) ([]Article, error)
+	GetRecentArticlesByFeedID(ctx context.Context, userID, feedID, limit int) ([]Article, error)
+	SetArticleState(ctx context.Context, userID, id int, isRead, isStarred *bool) (*Article, error)
+	MarkFeedArticlesRead(ctx context.Context, userID, feedID int) (int64, error)
+	MarkCategoryArticlesRead(ctx context.Context, userID, categoryID int) (int64, error)
+	MarkArticlesReadBefore(ctx context.Context, userID int, before time.Time) (int64, error)
+	SearchArticles(ctx context.Context, filter SearchFilter) ([]SearchResult, int, error)
+
+	// Categories
+	GetCategoryByID(ctx context.Context, userID, id int) (*Category, error)
+	GetCategoryByName(ctx context.Context, userID int, name string) (*Category, error)
+	CreateCategory(ctx context.Context, userID int, name string) (*Category, error)
+	ListCategories(ctx context.Context, userID int) ([]Category, error)
+	RenameCategory(ctx context.Context, id int, name string) (bool, error)
+	DeleteCategory(ctx context.Context, id int) (bool, error)
+	AddFeedToCategory(ctx context.Context, feedID, categoryID int) error
+	RemoveFeedFromCategory(ctx context.Context, feedID, categoryID int) (bool, error)
+	GetCategorySummaries(ctx context.Context, userID int) ([]CategorySummary, error)
+	GetCategorySummary(ctx context.Context, userID, id int) (*CategorySummary, error)
+	GetFeedCategories(ctx context.Context, userID, feedID int) ([]Category, error)
+
+	// Users, API tokens and feed tokens
+	CreateUser(ctx context.Context, name, email, passwordHash string) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	CreateAPIToken(ctx context.Context, userID int, tokenHash string) error
+	GetUserByTokenHash(ctx context.Context, tokenHash string) (*User, error)
+	DeleteAPIToken(ctx context.Context, tokenHash string) error
+	SetFeedToken(ctx context.Context, userID int, tokenHash string) error
+	GetUserByFeedTokenHash(ctx context.Context, tokenHash string) (*User, error)
+	DeleteFeedToken(ctx context.Context, userID int) error
+}
+
+var _ Store = (*DB)(nil)
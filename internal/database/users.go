@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// User represents a user account in the database
+type User struct {
+	ID           int
+	Name         string
+	Email        string
+	PasswordHash *string
+}
+
+// userColumns lists the columns scanned by scanUser
+const userColumns = "users.id, users.name, users.email, users.password_hash"
+
+// scanUser reads a user row, returning nil if there is none
+func scanUser(row *sql.Row) (*User, error) {
+	var user User
+	err := row.Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// CreateUser creates a user account. The first account ever created adopts
+// the data stored before accounts existed: it is subscribed to every feed, owns
+// the categories without an owner and inherits the global read state.
+// Registrations are serialized, so two concurrent ones cannot both be first.
+func (db *DB) CreateUser(ctx context.Context, name, email, passwordHash string) (*User, error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, db.dialect.lockUsers); err != nil {
+		return nil, err
+	}
+
+	var existing int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&existing); err != nil {
+		return nil, err
+	}
+
+	var id int
+	err = tx.QueryRowContext(
+		ctx,
+		"INSERT INTO users (name, email, password_hash, created_at) VALUES (?, ?, ?, ?) RETURNING id",
+		name, email, passwordHash, time.Now().UTC(),
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == 0 {
+		for _, query := range []string{
+			"INSERT INTO user_feeds (user_id, feed_id) SELECT CAST(? AS INTEGER), id FROM feeds",
+			"UPDATE categories SET user_id = ? WHERE user_id IS NULL",
+			"INSERT INTO user_articles (user_id, article_id, is_read) SELECT CAST(? AS INTEGER), id, TRUE FROM articles WHERE is_read = TRUE",
+		} {
+			if _, err := tx.ExecContext(ctx, query, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &User{ID: id, Name: name, Email: email, PasswordHash: &passwordHash}, nil
+}
+
+// GetUserByEmail retrieves a user by email
+func (db *DB) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	return scanUser(db.conn.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE email = ?", email))
+}
+
+// CreateAPIToken stores the hash of a new API token for a user
+func (db *DB) CreateAPIToken(ctx context.Context, userID int, tokenHash string) error {
+	_, err := db.conn.ExecContext(
+		ctx,
+		"INSERT INTO api_tokens (user_id, token_hash, created_at) VALUES (?, ?, ?)",
+		userID, tokenHash, time.Now().UTC(),
+	)
+	return err
+}
+
+// GetUserByTokenHash retrieves the owner of an API token and records that the token was used.
+// It returns nil if the token does not exist.
+func (db *DB) GetUserByTokenHash(ctx context.Context, tokenHash string) (*User, error) {
+	user, err := scanUser(db.conn.QueryRowContext(
+		ctx,
+		"SELECT "+userColumns+" FROM api_tokens JOIN users ON users.id = api_tokens.user_id WHERE api_tokens.token_hash = ?",
+		tokenHash,
+	))
+	if err != nil || user == nil {
+		return nil, err
+	}
+
+	// Limit the bookkeeping to one write per token and minute
+	now := time.Now().UTC()
+	_, err = db.conn.ExecContext(
+		ctx,
+		"UPDATE api_tokens SET last_used_at = ? WHERE token_hash = ? AND (last_used_at IS NULL OR last_used_at < ?)",
+		now, tokenHash, now.Add(-time.Minute),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeleteAPIToken revokes an API token
+func (db *DB) DeleteAPIToken(ctx context.Context, tokenHash string) error {
+	_, err := db.conn.ExecContext(ctx, "DELETE FROM api_tokens WHERE token_hash = ?", tokenHash)
+	return err
+}
+
+// SetFeedToken stores the hash of the feed token of a user, replacing the
+// previous one
+func (db *DB) SetFeedToken(ctx context.Context, userID int, tokenHash string) error {
+	_, err := db.conn.ExecContext(
+		ctx,
+		`INSERT INTO feed_tokens (user_id, token_hash, created_at) VALUES (?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = excluded.token_hash, created_at = excluded.created_at`,
+		userID, tokenHash, time.Now().UTC(),
+	)
+	return err
+}
+
+// GetUserByFeedTokenHash retrieves the owner of a feed token.
+// It returns nil if the token does not exist.
+func (db *DB) GetUserByFeedTokenHash(ctx context.Context, tokenHash string) (*User, error) {
+	return scanUser(db.conn.QueryRowContext(
+		ctx,
+		"SELECT "+userColumns+" FROM feed_tokens JOIN users ON users.id = feed_tokens.user_id WHERE feed_tokens.token_hash = ?",
+		tokenHash,
+	))
+}
+
+// DeleteFeedToken revokes the feed token of a user
+func (db *DB) DeleteFeedToken(ctx context.Context, userID int) error {
+	_, err := db.conn.ExecContext(ctx, "DELETE FROM feed_tokens WHERE user_id = ?", userID)
+	return err
+}
@@ -0,0 +1,339 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// Webhook delivery states
+const (
+	DeliveryPending   = "pending"
+	DeliverySending   = "sending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// EventArticleCreated is the event delivered for every new article
+const EventArticleCreated = "article.created"
+
+// Webhook is a subscription of a user to the new articles of their feeds
+type Webhook struct {
+	ID     int
+	UserID int
+	URL    string
+	// Secret is the key the payloads are signed with
+	Secret string
+	// FeedID, when set, limits the webhook to the articles of that feed
+	FeedID *int
+	// CategoryID, when set, limits the webhook to the feeds of that category
+	CategoryID *int
+	CreatedAt  time.Time
+}
+
+// WebhookDelivery is a payload queued for a webhook in the outbox
+type WebhookDelivery struct {
+	ID        int
+	WebhookID int
+	Event     string
+	// Payload is the JSON body sent to the webhook
+	Payload string
+	// State is one of DeliveryPending, DeliverySending, DeliveryDelivered and DeliveryDead
+	State string
+	// Attempts counts the attempts made so far, including the running one
+	Attempts int
+	// LastStatus is the HTTP status of the last attempt, nil if the receiver did not answer
+	LastStatus    *int
+	LastError     *string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	DeliveredAt   *time.Time
+}
+
+// ArticleEvent is the payload of an EventArticleCreated delivery
+type ArticleEvent struct {
+	Event   string         `json:"event"`
+	Article WebhookArticle `json:"article"`
+}
+
+// WebhookArticle is the article as delivered to webhooks
+type WebhookArticle struct {
+	ID              int        `json:"id"`
+	FeedID          int        `json:"feed_id"`
+	GUID            string     `json:"guid,omitempty"`
+	Link            string     `json:"link,omitempty"`
+	Title           string     `json:"title"`
+	Content         string     `json:"content,omitempty"`
+	Summary         string     `json:"summary,omitempty"`
+	PublicationDate *time.Time `json:"publication_date,omitempty"`
+	Authors         []string   `json:"authors,omitempty"`
+	Categories      []string   `json:"categories,omitempty"`
+}
+
+// webhookColumns lists the columns scanned by scanWebhook
+const webhookColumns = "id, user_id, url, secret, feed_id, category_id, created_at"
+
+// deliveryColumns lists the columns scanned by scanWebhookDelivery
+const deliveryColumns = "id, webhook_id, event, payload, state, attempts, last_status, last_error, " +
+	"created_at, next_attempt_at, delivered_at"
+
+// scanWebhook reads a webhook row, returning nil if there is none
+func scanWebhook(row interface{ Scan(dest ...any) error }) (*Webhook, error) {
+	var webhook Webhook
+	err := row.Scan(
+		&webhook.ID, &webhook.UserID, &webhook.URL, &webhook.Secret,
+		&webhook.FeedID, &webhook.CategoryID, &webhook.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// scanWebhookDelivery reads a delivery row, returning nil if there is none
+func scanWebhookDelivery(row interface{ Scan(dest ...any) error }) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := row.Scan(
+		&delivery.ID, &delivery.WebhookID, &delivery.Event, &delivery.Payload, &delivery.State,
+		&delivery.Attempts, &delivery.LastStatus, &delivery.LastError,
+		&delivery.CreatedAt, &delivery.NextAttemptAt, &delivery.DeliveredAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// CreateWebhook subscribes a user's webhook to the new articles of their
+// feeds, limited to a feed or a category when feedID or categoryID is set
+func (db *DB) CreateWebhook(ctx context.Context, userID int, url, secret string, feedID, categoryID *int) (*Webhook, error) {
+	return scanWebhook(db.conn.QueryRowContext(
+		ctx,
+		"INSERT INTO webhooks (user_id, url, secret, feed_id, category_id, created_at) VALUES (?, ?, ?, ?, ?, ?) RETURNING "+webhookColumns,
+		userID, url, secret, feedID, categoryID, time.Now().UTC(),
+	))
+}
+
+// GetWebhook retrieves a webhook of a user, or nil if the user has no such webhook
+func (db *DB) GetWebhook(ctx context.Context, userID, id int) (*Webhook, error) {
+	return scanWebhook(db.conn.QueryRowContext(
+		ctx,
+		"SELECT "+webhookColumns+" FROM webhooks WHERE id = ? AND user_id = ?",
+		id, userID,
+	))
+}
+
+// GetWebhookByID retrieves a webhook of any user, or nil if there is none
+func (db *DB) GetWebhookByID(ctx context.Context, id int) (*Webhook, error) {
+	return scanWebhook(db.conn.QueryRowContext(
+		ctx,
+		"SELECT "+webhookColumns+" FROM webhooks WHERE id = ?",
+		id,
+	))
+}
+
+// ListWebhooks retrieves the webhooks of a user, ordered by ID
+func (db *DB) ListWebhooks(ctx context.Context, userID int) ([]Webhook, error) {
+	rows, err := db.conn.QueryContext(
+		ctx,
+		"SELECT "+webhookColumns+" FROM webhooks WHERE user_id = ? ORDER BY id",
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// DeleteWebhook deletes a webhook of a user together with its deliveries
+func (db *DB) DeleteWebhook(ctx context.Context, userID, id int) (bool, error) {
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	deleted, err := deleteWebhooks(ctx, tx, "id = ? AND user_id = ?", id, userID)
+	if err != nil || deleted == 0 {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// deleteWebhooks deletes the webhooks matching the condition together with
+// their deliveries and returns how many webhooks there were
+func deleteWebhooks(ctx context.Context, tx *sqlTx, condition string, args ...any) (int64, error) {
+	_, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM webhook_deliveries WHERE webhook_id IN (SELECT id FROM webhooks WHERE "+condition+")",
+		args...,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM webhooks WHERE "+condition, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ListWebhookDeliveries retrieves a page of the deliveries of a webhook, newest first
+func (db *DB) ListWebhookDeliveries(ctx context.Context, webhookID, limit, offset int) ([]WebhookDelivery, error) {
+	rows, err := db.conn.QueryContext(
+		ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE webhook_id = ? ORDER BY id DESC LIMIT ? OFFSET ?",
+		webhookID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// CountWebhookDeliveries counts the deliveries of a webhook
+func (db *DB) CountWebhookDeliveries(ctx context.Context, webhookID int) (int, error) {
+	var count int
+	err := db.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id = ?", webhookID).Scan(&count)
+	return count, err
+}
+
+// ClaimWebhookDelivery marks the pending delivery due longest before now as
+// sending, counts the attempt and returns it, or nil if no delivery is due.
+// A delivery is claimed by one caller only.
+func (db *DB) ClaimWebhookDelivery(ctx context.Context, now time.Time) (*WebhookDelivery, error) {
+	return scanWebhookDelivery(db.conn.QueryRowContext(
+		ctx,
+		`UPDATE webhook_deliveries SET state = ?, attempts = attempts + 1
+		WHERE id = (SELECT id FROM webhook_deliveries WHERE state = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT 1)
+			AND state = ?
+		RETURNING `+deliveryColumns,
+		DeliverySending, DeliveryPending, now.UTC(), DeliveryPending,
+	))
+}
+
+// UpdateWebhookDelivery stores the outcome of a delivery attempt: its state,
+// the last status and error, and when it is due again
+func (db *DB) UpdateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) error {
+	_, err := db.conn.ExecContext(
+		ctx,
+		`UPDATE webhook_deliveries SET state = ?, last_status = ?, last_error = ?, next_attempt_at = ?, delivered_at = ?
+		WHERE id = ?`,
+		delivery.State, delivery.LastStatus, delivery.LastError, delivery.NextAttemptAt.UTC(),
+		utcTime(delivery.DeliveredAt), delivery.ID,
+	)
+	return err
+}
+
+// RequeueSendingWebhookDeliveries puts the deliveries left sending by a
+// stopped server back in the queue and returns how many there were
+func (db *DB) RequeueSendingWebhookDeliveries(ctx context.Context) (int64, error) {
+	result, err := db.conn.ExecContext(
+		ctx,
+		"UPDATE webhook_deliveries SET state = ? WHERE state = ?",
+		DeliveryPending, DeliverySending,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// feedWebhooks returns the IDs of the webhooks that receive the new articles
+// of a feed: those of its subscribers, unless limited to another feed or to
+// a category the feed is not in
+func feedWebhooks(ctx context.Context, tx *sqlTx, feedID int) ([]int, error) {
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT id FROM webhooks
+		WHERE user_id IN (SELECT user_id FROM user_feeds WHERE feed_id = ?)
+			AND (feed_id IS NULL OR feed_id = ?)
+			AND (category_id IS NULL OR category_id IN (SELECT category_id FROM feed_categories WHERE feed_id = ?))
+		ORDER BY id`,
+		feedID, feedID, feedID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// queueArticleDeliveries adds a delivery of a new article to the outbox of
+// every webhook
+func queueArticleDeliveries(ctx context.Context, tx *sqlTx, webhookIDs []int, feedID, articleID int, item NewArticle) error {
+	payload, err := json.Marshal(ArticleEvent{
+		Event: EventArticleCreated,
+		Article: WebhookArticle{
+			ID:              articleID,
+			FeedID:          feedID,
+			GUID:            item.GUID,
+			Link:            item.Link,
+			Title:           item.Title,
+			Content:         item.Content,
+			Summary:         item.Summary,
+			PublicationDate: item.PublicationDate,
+			Authors:         item.Authors,
+			Categories:      item.Categories,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, webhookID := range webhookIDs {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO webhook_deliveries (webhook_id, event, payload, state, attempts, created_at, next_attempt_at)
+			VALUES (?, ?, ?, ?, 0, ?, ?)`,
+			webhookID, EventArticleCreated, string(payload), DeliveryPending, now, now,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
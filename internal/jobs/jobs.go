@@ -0,0 +1,110 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"rss-aggregator/internal/database"
+	"rss-aggregator/internal/worker"
+)
+
+const (
+	// DefaultWorkers is the number of jobs run concurrently when not configured
+	DefaultWorkers = 2
+	// defaultPollInterval is how often idle workers look for queued jobs
+	defaultPollInterval = 5 * time.Second
+)
+
+// Runner carries out feed jobs
+type Runner interface {
+	// RunFeedJob subscribes the user of the job to its feed and returns the feed ID
+	RunFeedJob(ctx context.Context, job database.FeedJob) (int, error)
+}
+
+// Config holds worker pool settings
+type Config struct {
+	// Workers bounds the number of jobs run concurrently
+	Workers int
+	// PollInterval is how often idle workers check the queue
+	PollInterval time.Duration
+	// Wake, when signalled, makes an idle worker check the queue at once
+	Wake <-chan struct{}
+}
+
+// Pool runs the queued feed jobs with a bounded number of workers. The queue
+// lives in the database, so jobs outlive the process.
+type Pool struct {
+	db      database.Store
+	runner  Runner
+	workers *worker.Pool
+}
+
+// New creates a worker pool, filling unset config values with defaults
+func New(db database.Store, runner Runner, config Config) *Pool {
+	if config.Workers <= 0 {
+		config.Workers = DefaultWorkers
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+
+	p := &Pool{
+		db:     db,
+		runner: runner,
+	}
+	p.workers = worker.New("jobs", p.claim, worker.Config{
+		Workers:      config.Workers,
+		PollInterval: config.PollInterval,
+		Wake:         config.Wake,
+	})
+	return p
+}
+
+// Start puts the jobs interrupted by a previous shutdown back in the queue
+// and launches the workers in the background
+func (p *Pool) Start() {
+	requeued, err := p.db.RequeueRunningFeedJobs(context.Background())
+	if err != nil {
+		log.Printf("jobs: failed to requeue interrupted jobs: %v", err)
+	} else if requeued > 0 {
+		log.Printf("jobs: requeued %d interrupted jobs", requeued)
+	}
+
+	p.workers.Start()
+}
+
+// Stop stops taking jobs and waits for the running ones to finish, bounded
+// by the parser's fetch timeout
+func (p *Pool) Stop() {
+	p.workers.Stop()
+}
+
+// claim takes the next queued job
+func (p *Pool) claim(ctx context.Context) (func(ctx context.Context), error) {
+	job, err := p.db.ClaimFeedJob(ctx)
+	if err != nil || job == nil {
+		return nil, err
+	}
+	return func(ctx context.Context) { p.run(ctx, *job) }, nil
+}
+
+// run carries out a single job and records the outcome
+func (p *Pool) run(ctx context.Context, job database.FeedJob) {
+	var (
+		feedID *int
+		jobErr *string
+	)
+	id, err := p.runner.RunFeedJob(ctx, job)
+	if err != nil {
+		log.Printf("jobs: job %d (%s) failed: %v", job.ID, job.URL, err)
+		message := err.Error()
+		jobErr = &message
+	} else {
+		feedID = &id
+	}
+
+	if err := p.db.FinishFeedJob(ctx, job.ID, feedID, jobErr); err != nil {
+		log.Printf("jobs: failed to record the outcome of job %d: %v", job.ID, err)
+	}
+}
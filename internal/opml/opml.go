@@ -0,0 +1,163 @@
+package opml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// Document is an OPML 2.0 document
+type Document struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    Head     `xml:"head"`
+	Body    Body     `xml:"body"`
+}
+
+// Head holds document metadata
+type Head struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+// Body holds the top-level outlines
+type Body struct {
+	Outlines []Outline `xml:"outline"`
+}
+
+// Outline is either a subscription (it has an xmlUrl) or a folder of outlines
+type Outline struct {
+	Text     string    `xml:"text,attr"`
+	Title    string    `xml:"title,attr,omitempty"`
+	Type     string    `xml:"type,attr,omitempty"`
+	XMLURL   string    `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string    `xml:"htmlUrl,attr,omitempty"`
+	Outlines []Outline `xml:"outline"`
+}
+
+// Subscription is a feed listed in an OPML document
+type Subscription struct {
+	URL   string
+	Title string
+	// Categories are the folders the feed is listed in. A nested folder is
+	// named by its path, e.g. "Tech/Go".
+	Categories []string
+}
+
+// Parse reads the subscriptions of an OPML document. A feed listed in
+// several folders is returned once with all of its categories.
+func Parse(r io.Reader) ([]Subscription, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	var doc Document
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid OPML: %w", err)
+	}
+
+	var subscriptions []Subscription
+	index := make(map[string]int)
+	var walk func(outlines []Outline, folder string)
+	walk = func(outlines []Outline, folder string) {
+		for _, outline := range outlines {
+			url := strings.TrimSpace(outline.XMLURL)
+			if url == "" {
+				name := outlineName(outline)
+				if name == "" {
+					walk(outline.Outlines, folder)
+					continue
+				}
+				if folder != "" {
+					name = folder + "/" + name
+				}
+				walk(outline.Outlines, name)
+				continue
+			}
+
+			i, seen := index[url]
+			if !seen {
+				i = len(subscriptions)
+				index[url] = i
+				subscriptions = append(subscriptions, Subscription{URL: url, Title: outlineName(outline)})
+			}
+			if folder != "" && !slices.Contains(subscriptions[i].Categories, folder) {
+				subscriptions[i].Categories = append(subscriptions[i].Categories, folder)
+			}
+			walk(outline.Outlines, folder)
+		}
+	}
+	walk(doc.Body.Outlines, "")
+
+	return subscriptions, nil
+}
+
+// Write writes subscriptions as an OPML 2.0 document. Feeds without categories
+// are listed at the top level, the rest in one folder per category.
+func Write(w io.Writer, title string, subscriptions []Subscription) error {
+	doc := Document{
+		Version: "2.0",
+		Head: Head{
+			Title:       title,
+			DateCreated: time.Now().UTC().Format(time.RFC1123Z),
+		},
+	}
+
+	folders := make(map[string][]Outline)
+	for _, subscription := range subscriptions {
+		outline := Outline{
+			Text:   subscription.Title,
+			Title:  subscription.Title,
+			Type:   "rss",
+			XMLURL: subscription.URL,
+		}
+		if outline.Text == "" {
+			outline.Text = subscription.URL
+		}
+
+		if len(subscription.Categories) == 0 {
+			doc.Body.Outlines = append(doc.Body.Outlines, outline)
+			continue
+		}
+		for _, category := range subscription.Categories {
+			folders[category] = append(folders[category], outline)
+		}
+	}
+
+	names := make([]string, 0, len(folders))
+	for name := range folders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		doc.Body.Outlines = append(doc.Body.Outlines, Outline{
+			Text:     name,
+			Title:    name,
+			Outlines: folders[name],
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// outlineName returns the display name of an outline
+func outlineName(outline Outline) string {
+	if name := strings.TrimSpace(outline.Text); name != "" {
+		return name
+	}
+	return strings.TrimSpace(outline.Title)
+}
@@ -0,0 +1,213 @@
+package rss
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	"golang.org/x/net/html"
+)
+
+// Feed MIME types advertised by <link rel="alternate"> tags
+const (
+	TypeRSS      = "application/rss+xml"
+	TypeAtom     = "application/atom+xml"
+	TypeJSONFeed = "application/feed+json"
+)
+
+// commonFeedPaths are probed when a page does not advertise its feeds
+var commonFeedPaths = []string{"/feed", "/rss.xml", "/atom.xml", "/feed.xml", "/index.xml", "/feed.json"}
+
+// FeedCandidate is a feed found by discovery
+type FeedCandidate struct {
+	URL   string
+	Title string
+	// Type is the feed MIME type, one of TypeRSS, TypeAtom and TypeJSONFeed
+	Type string
+}
+
+// IsNotFeed reports whether err means the fetched document is not a feed,
+// e.g. the URL points to an HTML page
+func IsNotFeed(err error) bool {
+	return errors.Is(err, gofeed.ErrFeedTypeNotDetected)
+}
+
+// Discover finds the feeds of a page. A URL that already points to a feed is
+// its own only candidate. For an HTML page the candidates are the feeds from
+// its <link rel="alternate"> tags in page order; a page without such tags is
+// probed for feeds at common paths like /feed and /rss.xml. The first
+// candidate is the best one; an empty list means no feed was found.
+func (p *Parser) Discover(ctx context.Context, pageURL string) ([]FeedCandidate, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	body, base, err := p.download(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	if candidate, ok := p.feedCandidate(base, body); ok {
+		return []FeedCandidate{candidate}, nil
+	}
+
+	candidates := linkedFeeds(base, body)
+	if len(candidates) > 0 {
+		return candidates, nil
+	}
+
+	for _, path := range commonFeedPaths {
+		probeURL := base.ResolveReference(&url.URL{Path: path})
+		body, final, err := p.download(ctx, probeURL.String())
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			continue
+		}
+		if candidate, ok := p.feedCandidate(final, body); ok && !containsURL(candidates, candidate.URL) {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates, nil
+}
+
+// download reads up to maxFeedSize bytes of a document and returns them
+// together with the URL it was served from after redirects. The limit is the
+// one Fetch applies, so any feed Fetch accepts is recognized as a feed here.
+func (p *Parser) download(ctx context.Context, rawURL string) ([]byte, *url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, &HTTPError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, p.maxFeedSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, resp.Request.URL, nil
+}
+
+// feedCandidate returns the document as a candidate if it is a feed
+func (p *Parser) feedCandidate(feedURL *url.URL, body []byte) (FeedCandidate, bool) {
+	var feedType string
+	switch gofeed.DetectFeedType(bytes.NewReader(body)) {
+	case gofeed.FeedTypeRSS:
+		feedType = TypeRSS
+	case gofeed.FeedTypeAtom:
+		feedType = TypeAtom
+	case gofeed.FeedTypeJSON:
+		feedType = TypeJSONFeed
+	default:
+		return FeedCandidate{}, false
+	}
+
+	feed, err := p.fp.Parse(bytes.NewReader(body))
+	if err != nil {
+		return FeedCandidate{}, false
+	}
+	return FeedCandidate{URL: feedURL.String(), Title: feed.Title, Type: feedType}, true
+}
+
+// linkedFeeds collects the feeds advertised by <link rel="alternate"> tags of
+// an HTML page, resolving their URLs against the page URL or its <base>
+func linkedFeeds(pageURL *url.URL, body []byte) []FeedCandidate {
+	candidates := []FeedCandidate{}
+	base := pageURL
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return candidates
+		case html.StartTagToken, html.SelfClosingTagToken:
+		default:
+			continue
+		}
+
+		token := tokenizer.Token()
+		switch token.Data {
+		case "base":
+			if href, err := url.Parse(attr(token, "href")); err == nil && base == pageURL {
+				base = pageURL.ResolveReference(href)
+			}
+		case "link":
+			feedType := feedMIMEType(attr(token, "type"))
+			if feedType == "" || !hasToken(attr(token, "rel"), "alternate") {
+				continue
+			}
+			href, err := url.Parse(strings.TrimSpace(attr(token, "href")))
+			if err != nil || href.String() == "" {
+				continue
+			}
+
+			feedURL := base.ResolveReference(href).String()
+			if !containsURL(candidates, feedURL) {
+				candidates = append(candidates, FeedCandidate{
+					URL:   feedURL,
+					Title: strings.TrimSpace(attr(token, "title")),
+					Type:  feedType,
+				})
+			}
+		}
+	}
+}
+
+// feedMIMEType normalizes a link type, returning "" for non-feed types
+func feedMIMEType(value string) string {
+	mediaType, _, err := mime.ParseMediaType(value)
+	if err != nil {
+		return ""
+	}
+	switch mediaType {
+	case TypeRSS, TypeAtom, TypeJSONFeed:
+		return mediaType
+	}
+	return ""
+}
+
+// attr returns the value of a tag attribute or "" if it is missing
+func attr(token html.Token, name string) string {
+	for _, attribute := range token.Attr {
+		if attribute.Key == name {
+			return attribute.Val
+		}
+	}
+	return ""
+}
+
+// hasToken reports whether a space-separated list such as rel contains token
+func hasToken(list, token string) bool {
+	for _, field := range strings.Fields(list) {
+		if strings.EqualFold(field, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsURL reports whether a candidate with the URL was already found
+func containsURL(candidates []FeedCandidate, feedURL string) bool {
+	for _, candidate := range candidates {
+		if candidate.URL == feedURL {
+			return true
+		}
+	}
+	return false
+}
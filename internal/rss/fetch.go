@@ -0,0 +1,183 @@
+package rss
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// userAgent identifies the aggregator to feed publishers
+const userAgent = "rss-aggregator/1.0"
+
+// CacheValidators holds the HTTP validators returned by a previous fetch
+type CacheValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// FetchResult is the outcome of a conditional feed fetch
+type FetchResult struct {
+	// Feed is nil when the server answered 304 Not Modified
+	Feed        *FeedInfo
+	NotModified bool
+	// Validators to send with the next fetch
+	Validators CacheValidators
+	// MaxAge is the freshness lifetime from Cache-Control, zero if absent
+	MaxAge time.Duration
+}
+
+// HTTPError is returned when the feed server answers with an unexpected status
+type HTTPError struct {
+	StatusCode int
+	// RetryAfter is the delay requested by the Retry-After header, zero if absent
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("http error: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// TooLargeError is returned when the feed body exceeds the parser's size limit
+type TooLargeError struct {
+	Limit int64
+}
+
+func (e *TooLargeError) Error() string {
+	return fmt.Sprintf("feed is larger than %d bytes", e.Limit)
+}
+
+// ParseError is returned when the feed server answered but the document
+// could not be parsed as a feed, e.g. malformed XML or an HTML page
+type ParseError struct {
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// IsTimeout reports whether err means the feed host did not answer in time
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Fetch downloads and parses a feed, sending the validators as
+// If-None-Match and If-Modified-Since so unchanged feeds cost a 304.
+// The download gives up when ctx is done, the parser's timeout passes or the
+// body exceeds its size limit.
+func (p *Parser) Fetch(ctx context.Context, url string, validators CacheValidators) (*FetchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	now := time.Now()
+	result := &FetchResult{
+		Validators: validators,
+		MaxAge:     parseMaxAge(resp.Header.Get("Cache-Control")),
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		result.Validators.ETag = etag
+	}
+	if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+		result.Validators.LastModified = lastModified
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		result.NotModified = true
+		return result, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to parse RSS feed: %w", &HTTPError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), now),
+		})
+	}
+
+	// Read one byte past the limit to tell a feed of exactly the limit
+	// from a larger one
+	body, err := io.ReadAll(io.LimitReader(resp.Body, p.maxFeedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+	}
+	if int64(len(body)) > p.maxFeedSize {
+		return nil, fmt.Errorf("failed to parse RSS feed: %w", &TooLargeError{Limit: p.maxFeedSize})
+	}
+	feed, err := p.fp.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSS feed: %w", &ParseError{Err: err})
+	}
+
+	result.Feed = convertFeed(feed)
+	addFormatDetails(result.Feed, body)
+	sanitizeItems(result.Feed)
+	return result, nil
+}
+
+// parseMaxAge extracts max-age from a Cache-Control header.
+// no-cache and no-store disable caching and yield zero.
+func parseMaxAge(header string) time.Duration {
+	var maxAge time.Duration
+	for _, directive := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-cache", "no-store":
+			return 0
+		case "max-age":
+			seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err == nil && seconds > 0 {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return maxAge
+}
+
+// parseRetryAfter reads a Retry-After header given either as
+// delay-seconds or as an HTTP date
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+package rss
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const smallFeed = `<?xml version="1.0"?><rss version="2.0"><channel><title>Small</title>
+<item><title>Only article</title><description>Content</description></item></channel></rss>`
+
+func TestFetch_MaxFeedSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/huge" {
+			// Never ends: only the limit stops the download
+			w.Write([]byte(smallFeed[:len(smallFeed)-len("</channel></rss>")]))
+			filler := []byte("<!--" + strings.Repeat("x", 4096) + "-->")
+			for r.Context().Err() == nil {
+				if _, err := w.Write(filler); err != nil {
+					return
+				}
+			}
+			return
+		}
+		w.Write([]byte(smallFeed))
+	}))
+	defer server.Close()
+
+	limit := int64(len(smallFeed))
+	parser := NewParserWithLimits(5*time.Second, limit)
+
+	t.Run("feed within the limit", func(t *testing.T) {
+		result, err := parser.Fetch(context.Background(), server.URL, CacheValidators{})
+		require.NoError(t, err)
+		assert.Equal(t, "Small", result.Feed.Title)
+		assert.Len(t, result.Feed.Items, 1)
+	})
+
+	t.Run("feed over the limit", func(t *testing.T) {
+		_, err := parser.Fetch(context.Background(), server.URL+"/huge", CacheValidators{})
+		var tooLarge *TooLargeError
+		require.True(t, errors.As(err, &tooLarge), "unexpected error %v", err)
+		assert.Equal(t, limit, tooLarge.Limit)
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		assert.Equal(t, int64(DefaultMaxFeedSize), NewParser().maxFeedSize)
+		assert.Equal(t, int64(DefaultMaxFeedSize), NewParserWithLimits(time.Second, 0).maxFeedSize)
+	})
+}
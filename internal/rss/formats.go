@@ -0,0 +1,162 @@
+package rss
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// Source formats reported in FeedInfo.Format
+const (
+	FormatRSS      = "rss"
+	FormatAtom     = "atom"
+	FormatJSONFeed = "json"
+)
+
+// Content types reported in Item.ContentType
+const (
+	ContentTypeHTML  = "html"
+	ContentTypeXHTML = "xhtml"
+	ContentTypeText  = "text"
+)
+
+// atomDocument holds the parts of an Atom document gofeed does not preserve
+type atomDocument struct {
+	Entries []struct {
+		Published string    `xml:"published"`
+		Content   *atomText `xml:"content"`
+		Summary   *atomText `xml:"summary"`
+	} `xml:"entry"`
+}
+
+// atomText is an Atom text construct or content element
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",innerxml"`
+}
+
+// jsonFeedDocument holds the raw members of JSON Feed items
+type jsonFeedDocument struct {
+	Items []map[string]json.RawMessage `json:"items"`
+}
+
+// addFormatDetails reads the format-specific item data that gofeed's universal
+// feed drops from the raw document: the content types of Atom entries and the
+// content kind, banner image and extensions of JSON Feed items. A document that
+// cannot be read again keeps the details convertFeed assumed.
+func addFormatDetails(feed *FeedInfo, body []byte) {
+	switch feed.Format {
+	case FormatAtom:
+		addAtomDetails(feed, body)
+	case FormatJSONFeed:
+		addJSONFeedDetails(feed, body)
+	}
+}
+
+// addAtomDetails sets the content type of each entry from the type attribute
+// of its content or, when the content came from the summary, of its summary.
+// A summary given besides the content keeps its own type.
+// gofeed falls back to <updated> for the publication date, so entries without
+// <published> lose it.
+func addAtomDetails(feed *FeedInfo, body []byte) {
+	var document atomDocument
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.CharsetReader = charset.NewReaderLabel
+	if err := decoder.Decode(&document); err != nil || len(document.Entries) != len(feed.Items) {
+		return
+	}
+
+	for i, entry := range document.Entries {
+		text := entry.Summary
+		if entry.Content != nil && strings.TrimSpace(entry.Content.Value) != "" {
+			text = entry.Content
+			if entry.Summary != nil {
+				feed.Items[i].summaryType = atomContentType(entry.Summary.Type)
+			}
+		}
+		if text != nil {
+			feed.Items[i].ContentType = atomContentType(text.Type)
+		}
+		if strings.TrimSpace(entry.Published) == "" {
+			feed.Items[i].Published = nil
+		}
+	}
+}
+
+// atomContentType maps the type attribute of an Atom text onto a content type.
+// Atom text without a type is plain text.
+func atomContentType(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case value == "html" || value == "text/html":
+		return ContentTypeHTML
+	case strings.Contains(value, "xhtml"):
+		return ContentTypeXHTML
+	default:
+		return ContentTypeText
+	}
+}
+
+// jsonFeedVersionPrefix starts the version URL of a JSON Feed document
+const jsonFeedVersionPrefix = "https://jsonfeed.org/version/"
+
+// addJSONFeedDetails tells content_html from content_text and keeps the
+// banner image and extension members of each item. The version URL is
+// shortened to its number.
+//
+// An item with both keeps only content_html, as gofeed does: JSON Feed makes
+// content_text a plain text rendering of the same content, which readers get
+// back by asking for format=text.
+func addJSONFeedDetails(feed *FeedInfo, body []byte) {
+	feed.FormatVersion = strings.TrimPrefix(feed.FormatVersion, jsonFeedVersionPrefix)
+
+	var document jsonFeedDocument
+	if err := json.Unmarshal(body, &document); err != nil || len(document.Items) != len(feed.Items) {
+		return
+	}
+
+	for i, members := range document.Items {
+		item := &feed.Items[i]
+		// Without content_html the content is content_text or, lacking it,
+		// the summary, and both are plain text
+		if jsonString(members["content_html"]) == "" {
+			item.ContentType = ContentTypeText
+		}
+		// A summary given besides the content is plain text as well
+		item.summaryType = ContentTypeText
+		item.BannerImage = strings.TrimSpace(jsonString(members["banner_image"]))
+		item.Extensions = jsonExtensions(members)
+	}
+}
+
+// jsonString decodes a JSON string member, returning "" for anything else
+func jsonString(raw json.RawMessage) string {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return ""
+	}
+	return value
+}
+
+// jsonExtensions collects the members whose names start with an underscore
+// into a JSON object, or returns nil if there are none
+func jsonExtensions(members map[string]json.RawMessage) json.RawMessage {
+	extensions := make(map[string]json.RawMessage)
+	for name, value := range members {
+		if strings.HasPrefix(name, "_") {
+			extensions[name] = value
+		}
+	}
+	if len(extensions) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(extensions)
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
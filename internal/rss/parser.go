@@ -1,21 +1,60 @@
 package rss
 
 import (
-	"fmt"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mmcdole/gofeed"
 )
 
+const (
+	// DefaultFetchTimeout bounds a single feed download when not configured
+	DefaultFetchTimeout = 30 * time.Second
+	// DefaultMaxFeedSize bounds the body of a feed download when not configured
+	DefaultMaxFeedSize = 10 << 20
+)
+
 // Parser handles RSS feed parsing
 type Parser struct {
-	fp *gofeed.Parser
+	fp     *gofeed.Parser
+	client *http.Client
+	// timeout bounds each fetch on top of the caller's context
+	timeout time.Duration
+	// maxFeedSize bounds the body of each fetch in bytes
+	maxFeedSize int64
 }
 
-// NewParser creates a new RSS parser
+// NewParser creates a new RSS parser with the default fetch timeout
 func NewParser() *Parser {
+	return NewParserWithTimeout(DefaultFetchTimeout)
+}
+
+// NewParserWithTimeout creates a new RSS parser whose fetches give up after timeout
+func NewParserWithTimeout(timeout time.Duration) *Parser {
+	return NewParserWithLimits(timeout, DefaultMaxFeedSize)
+}
+
+// NewParserWithLimits creates a new RSS parser whose fetches give up after
+// timeout or once the feed grows beyond maxFeedSize bytes
+func NewParserWithLimits(timeout time.Duration, maxFeedSize int64) *Parser {
+	if timeout <= 0 {
+		timeout = DefaultFetchTimeout
+	}
+	if maxFeedSize <= 0 {
+		maxFeedSize = DefaultMaxFeedSize
+	}
+
 	return &Parser{
-		fp: gofeed.NewParser(),
+		fp:          gofeed.NewParser(),
+		client:      &http.Client{},
+		timeout:     timeout,
+		maxFeedSize: maxFeedSize,
 	}
 }
 
@@ -23,27 +62,101 @@ func NewParser() *Parser {
 type FeedInfo struct {
 	Title       string
 	Description string
-	Items       []Item
+	// Format is the source format: FormatRSS, FormatAtom or FormatJSONFeed
+	Format string
+	// FormatVersion is the version of the format, e.g. "2.0" for RSS 2.0
+	FormatVersion string
+	Items         []Item
 }
 
 // Item represents a parsed RSS item
 type Item struct {
-	Title         string
-	Content       string
+	GUID  string
+	Link  string
+	Title string
+	// Content is the full text of the item or, lacking it, the summary,
+	// sanitized unless it is plain text
+	Content string
+	// RawContent is Content as the feed delivered it, before sanitization
+	RawContent string
+	// ContentType tells how Content is marked up: ContentTypeHTML,
+	// ContentTypeXHTML or ContentTypeText
+	ContentType string
+	// Summary is a short description given besides the full content. It is
+	// always sanitized HTML: a plain text summary is escaped.
+	Summary string
+	// summaryType tells how the feed marked up Summary, like ContentType;
+	// empty means HTML
+	summaryType string
+	// PublicationDate is the publication date or, lacking it, the update date
 	PublicationDate *time.Time
+	// Published is the publication date as given by the publisher, if any
+	Published  *time.Time
+	Updated    *time.Time
+	Authors    []string
+	Categories []string
+	Enclosures []Enclosure
+	ImageURL   string
+	// BannerImage is the JSON Feed banner_image
+	BannerImage string
+	// Extensions holds the JSON Feed extension members, those named with a
+	// leading underscore, as a JSON object; nil if there are none
+	Extensions json.RawMessage
+}
+
+// Enclosure represents media attached to an item, such as podcast audio
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length int64
+}
+
+// Key returns a stable identity for the item within its feed: the GUID when
+// the feed provides one, otherwise a hash of the link or, lacking a link,
+// of the title and raw content
+func (i Item) Key() string {
+	if i.GUID != "" {
+		return i.GUID
+	}
+
+	content := i.RawContent
+	if content == "" {
+		content = i.Content
+	}
+
+	var sum [sha256.Size]byte
+	if i.Link != "" {
+		sum = sha256.Sum256([]byte(i.Link))
+	} else {
+		sum = sha256.Sum256([]byte(i.Title + "\x00" + content))
+	}
+	return "sha256:" + hex.EncodeToString(sum[:])
 }
 
 // ParseFeed parses an RSS feed from a URL
 func (p *Parser) ParseFeed(url string) (*FeedInfo, error) {
-	feed, err := p.fp.ParseURL(url)
+	return p.ParseURLWithContext(context.Background(), url)
+}
+
+// ParseURLWithContext parses an RSS feed from a URL, giving up when ctx is done
+func (p *Parser) ParseURLWithContext(ctx context.Context, url string) (*FeedInfo, error) {
+	result, err := p.Fetch(ctx, url, CacheValidators{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+		return nil, err
 	}
 
+	return result.Feed, nil
+}
+
+// convertFeed maps a gofeed feed onto FeedInfo. Content is assumed to be HTML,
+// as in RSS; addFormatDetails corrects it for Atom and JSON Feed.
+func convertFeed(feed *gofeed.Feed) *FeedInfo {
 	feedInfo := &FeedInfo{
-		Title:       feed.Title,
-		Description: feed.Description,
-		Items:       make([]Item, 0, len(feed.Items)),
+		Title:         feed.Title,
+		Description:   feed.Description,
+		Format:        feed.FeedType,
+		FormatVersion: feed.FeedVersion,
+		Items:         make([]Item, 0, len(feed.Items)),
 	}
 
 	for _, item := range feed.Items {
@@ -53,19 +166,88 @@ func (p *Parser) ParseFeed(url string) (*FeedInfo, error) {
 		} else if item.UpdatedParsed != nil {
 			pubDate = item.UpdatedParsed
 		}
+		// Store dates in UTC so they sort and compare consistently across feeds
+		pubDate = utcTime(pubDate)
 
-		content := item.Content
+		content, summary := item.Content, item.Description
 		if content == "" {
-			content = item.Description
+			content, summary = item.Description, ""
 		}
 
 		feedInfo.Items = append(feedInfo.Items, Item{
-			Title:         item.Title,
-			Content:       content,
+			GUID:            strings.TrimSpace(item.GUID),
+			Link:            strings.TrimSpace(item.Link),
+			Title:           item.Title,
+			Content:         content,
+			ContentType:     ContentTypeHTML,
+			Summary:         summary,
 			PublicationDate: pubDate,
+			Published:       utcTime(item.PublishedParsed),
+			Updated:         utcTime(item.UpdatedParsed),
+			Authors:         authorNames(item.Authors),
+			Categories:      item.Categories,
+			Enclosures:      convertEnclosures(item.Enclosures),
+			ImageURL:        itemImage(item),
 		})
 	}
 
-	return feedInfo, nil
+	return feedInfo
+}
+
+// utcTime returns a copy of t in UTC, or nil
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// itemImage returns the image gofeed detected for the item, falling back
+// to a media:thumbnail, which gofeed does not consider
+func itemImage(item *gofeed.Item) string {
+	if item.Image != nil && item.Image.URL != "" {
+		return item.Image.URL
+	}
+	for _, thumbnail := range item.Extensions["media"]["thumbnail"] {
+		if url := thumbnail.Attrs["url"]; url != "" {
+			return url
+		}
+	}
+	return ""
+}
+
+// authorNames lists author names, falling back to the email when a name is missing
+func authorNames(authors []*gofeed.Person) []string {
+	names := make([]string, 0, len(authors))
+	for _, author := range authors {
+		if author == nil {
+			continue
+		}
+		name := strings.TrimSpace(author.Name)
+		if name == "" {
+			name = strings.TrimSpace(author.Email)
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
+// convertEnclosures keeps enclosures that have a URL
+func convertEnclosures(enclosures []*gofeed.Enclosure) []Enclosure {
+	converted := make([]Enclosure, 0, len(enclosures))
+	for _, enclosure := range enclosures {
+		if enclosure == nil || enclosure.URL == "" {
+			continue
+		}
+		length, _ := strconv.ParseInt(enclosure.Length, 10, 64)
+		converted = append(converted, Enclosure{
+			URL:    enclosure.URL,
+			Type:   enclosure.Type,
+			Length: length,
+		})
+	}
+	return converted
+}
@@ -0,0 +1,301 @@
+package rss
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedElements lists the elements Sanitize keeps, each with the attributes
+// it may carry. Elements that are neither allowed nor dropped are unwrapped:
+// their children stay in place of them.
+var allowedElements = map[atom.Atom][]string{
+	atom.A:          {"href", "title"},
+	atom.Abbr:       {"title"},
+	atom.B:          nil,
+	atom.Blockquote: {"cite"},
+	atom.Br:         nil,
+	atom.Caption:    nil,
+	atom.Cite:       nil,
+	atom.Code:       nil,
+	atom.Dd:         nil,
+	atom.Del:        nil,
+	atom.Div:        nil,
+	atom.Dl:         nil,
+	atom.Dt:         nil,
+	atom.Em:         nil,
+	atom.Figcaption: nil,
+	atom.Figure:     nil,
+	atom.H1:         nil,
+	atom.H2:         nil,
+	atom.H3:         nil,
+	atom.H4:         nil,
+	atom.H5:         nil,
+	atom.H6:         nil,
+	atom.Hr:         nil,
+	atom.I:          nil,
+	atom.Img:        {"src", "alt", "title", "width", "height"},
+	atom.Ins:        nil,
+	atom.Li:         nil,
+	atom.Mark:       nil,
+	atom.Ol:         {"start"},
+	atom.P:          nil,
+	atom.Pre:        nil,
+	atom.Q:          {"cite"},
+	atom.S:          nil,
+	atom.Small:      nil,
+	atom.Span:       nil,
+	atom.Strong:     nil,
+	atom.Sub:        nil,
+	atom.Sup:        nil,
+	atom.Table:      nil,
+	atom.Tbody:      nil,
+	atom.Td:         {"colspan", "rowspan"},
+	atom.Tfoot:      nil,
+	atom.Th:         {"colspan", "rowspan"},
+	atom.Thead:      nil,
+	atom.Time:       {"datetime"},
+	atom.Tr:         nil,
+	atom.U:          nil,
+	atom.Ul:         nil,
+}
+
+// droppedElements are removed together with everything inside them
+var droppedElements = map[atom.Atom]bool{
+	atom.Base:     true,
+	atom.Button:   true,
+	atom.Embed:    true,
+	atom.Form:     true,
+	atom.Frame:    true,
+	atom.Frameset: true,
+	atom.Head:     true,
+	atom.Iframe:   true,
+	atom.Input:    true,
+	atom.Link:     true,
+	atom.Math:     true,
+	atom.Meta:     true,
+	atom.Noscript: true,
+	atom.Object:   true,
+	atom.Script:   true,
+	atom.Select:   true,
+	atom.Style:    true,
+	atom.Svg:      true,
+	atom.Template: true,
+	atom.Textarea: true,
+	atom.Title:    true,
+}
+
+// urlAttributes are the attributes holding URLs, with the schemes they accept
+var urlAttributes = map[string][]string{
+	"href": {"http", "https", "mailto"},
+	"src":  {"http", "https"},
+	"cite": {"http", "https"},
+}
+
+// blockElements start a new line in PlainText
+var blockElements = map[atom.Atom]bool{
+	atom.Blockquote: true,
+	atom.Br:         true,
+	atom.Caption:    true,
+	atom.Dd:         true,
+	atom.Div:        true,
+	atom.Dl:         true,
+	atom.Dt:         true,
+	atom.Figcaption: true,
+	atom.Figure:     true,
+	atom.H1:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.H5:         true,
+	atom.H6:         true,
+	atom.Hr:         true,
+	atom.Li:         true,
+	atom.Ol:         true,
+	atom.P:          true,
+	atom.Pre:        true,
+	atom.Table:      true,
+	atom.Tr:         true,
+	atom.Ul:         true,
+}
+
+// Sanitize makes HTML from a feed safe to render. Only allowlisted elements
+// and attributes are kept: scripts, styles, frames, forms and comments are
+// removed, as are event handlers and URLs with schemes other than http, https
+// and mailto, such as javascript:. Relative URLs are resolved against base,
+// usually the item link, and links get rel="noopener".
+func Sanitize(content, base string) string {
+	if strings.TrimSpace(content) == "" {
+		return content
+	}
+
+	container := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := html.ParseFragment(strings.NewReader(content), container)
+	if err != nil {
+		return html.EscapeString(content)
+	}
+	for _, node := range nodes {
+		container.AppendChild(node)
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil || !baseURL.IsAbs() {
+		baseURL = nil
+	}
+	sanitizeChildren(container, baseURL)
+
+	var sb strings.Builder
+	for child := container.FirstChild; child != nil; child = child.NextSibling {
+		if err := html.Render(&sb, child); err != nil {
+			return ""
+		}
+	}
+	return sb.String()
+}
+
+// sanitizeChildren applies the allowlist to the children of parent
+func sanitizeChildren(parent *html.Node, base *url.URL) {
+	for child := parent.FirstChild; child != nil; {
+		next := child.NextSibling
+
+		switch child.Type {
+		case html.TextNode:
+		case html.ElementNode:
+			if droppedElements[child.DataAtom] {
+				parent.RemoveChild(child)
+				break
+			}
+			sanitizeChildren(child, base)
+			if attributes, ok := allowedElements[child.DataAtom]; ok {
+				child.Attr = sanitizeAttributes(child, attributes, base)
+			} else {
+				unwrap(child)
+				parent.RemoveChild(child)
+			}
+		default:
+			parent.RemoveChild(child)
+		}
+
+		child = next
+	}
+}
+
+// unwrap moves the children of node in front of it
+func unwrap(node *html.Node) {
+	for grandchild := node.FirstChild; grandchild != nil; grandchild = node.FirstChild {
+		node.RemoveChild(grandchild)
+		node.Parent.InsertBefore(grandchild, node)
+	}
+}
+
+// sanitizeAttributes keeps the allowed attributes of node, resolving and
+// checking the URLs among them. Links get rel="noopener".
+func sanitizeAttributes(node *html.Node, allowed []string, base *url.URL) []html.Attribute {
+	var kept []html.Attribute
+	for _, attr := range node.Attr {
+		if attr.Namespace != "" || !slices.Contains(allowed, attr.Key) {
+			continue
+		}
+		if schemes, ok := urlAttributes[attr.Key]; ok {
+			resolved, ok := safeURL(attr.Val, schemes, base)
+			if !ok {
+				continue
+			}
+			attr.Val = resolved
+		}
+		kept = append(kept, attr)
+	}
+
+	if node.DataAtom == atom.A {
+		kept = append(kept, html.Attribute{Key: "rel", Val: "noopener"})
+	}
+	return kept
+}
+
+// safeURL resolves value against base and reports whether its scheme is one
+// of schemes. A relative URL is kept as is when there is no base.
+func safeURL(value string, schemes []string, base *url.URL) (string, bool) {
+	parsed, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return "", false
+	}
+	if base != nil {
+		parsed = base.ResolveReference(parsed)
+	}
+	if parsed.Scheme != "" && !slices.Contains(schemes, strings.ToLower(parsed.Scheme)) {
+		return "", false
+	}
+	return parsed.String(), true
+}
+
+// PlainText returns the text of HTML content without markup, one line per
+// paragraph or other block. Scripts and other dropped elements contribute
+// no text.
+func PlainText(content string) string {
+	container := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := html.ParseFragment(strings.NewReader(content), container)
+	if err != nil {
+		return content
+	}
+
+	var sb strings.Builder
+	for _, node := range nodes {
+		writeText(&sb, node)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(sb.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeText appends the text of node to sb, breaking lines around blocks
+func writeText(sb *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		sb.WriteString(strings.ReplaceAll(node.Data, "\n", " "))
+		return
+	case html.ElementNode:
+		if droppedElements[node.DataAtom] {
+			return
+		}
+	default:
+		return
+	}
+
+	block := blockElements[node.DataAtom]
+	if block {
+		sb.WriteByte('\n')
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeText(sb, child)
+	}
+	if block {
+		sb.WriteByte('\n')
+	}
+}
+
+// sanitizeItems keeps the fetched content of each item in RawContent and
+// replaces Content with its sanitized HTML; plain text content is left as is.
+// The summary has a type of its own and always becomes HTML: sanitized if it
+// is marked up, escaped if it is plain text.
+func sanitizeItems(feed *FeedInfo) {
+	for i := range feed.Items {
+		item := &feed.Items[i]
+		item.RawContent = item.Content
+		if item.ContentType != ContentTypeText {
+			item.Content = Sanitize(item.Content, item.Link)
+		}
+		if item.summaryType == ContentTypeText {
+			item.Summary = html.EscapeString(item.Summary)
+		} else {
+			item.Summary = Sanitize(item.Summary, item.Link)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package rss
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	const base = "https://example.com/posts/1"
+
+	tests := []struct {
+		name    string
+		content string
+		base    string
+		want    string
+	}{
+		// Scripts, styles and other dropped elements
+		{
+			name:    "script removed with its text",
+			content: `<p>Before</p><script>alert("xss")</script><p>After</p>`,
+			want:    `<p>Before</p><p>After</p>`,
+		},
+		{
+			name:    "style removed with its rules",
+			content: `<style>body { display: none }</style><p>Text</p>`,
+			want:    `<p>Text</p>`,
+		},
+		{
+			name:    "nested script removed",
+			content: `<div><span>Text<script src="https://evil.example/x.js"></script></span></div>`,
+			want:    `<div><span>Text</span></div>`,
+		},
+		{
+			name:    "iframe, form and comments removed",
+			content: `<p>Text</p><iframe src="https://example.com"></iframe><form><input name="q"></form><!-- note -->`,
+			want:    `<p>Text</p>`,
+		},
+		{
+			name:    "unknown element unwrapped",
+			content: `<article><p>Kept <blink>blinking</blink></p></article>`,
+			want:    `<p>Kept blinking</p>`,
+		},
+
+		// javascript: and data: URLs
+		{
+			name:    "javascript link",
+			content: `<a href="javascript:alert(1)">Click</a>`,
+			want:    `<a rel="noopener">Click</a>`,
+		},
+		{
+			name:    "javascript link in mixed case with spaces",
+			content: `<a href="  JavaScript:alert(1)">Click</a>`,
+			want:    `<a rel="noopener">Click</a>`,
+		},
+		{
+			name:    "data image",
+			content: `<img src="data:image/png;base64,iVBORw0KGgo=" alt="Pixel">`,
+			want:    `<img alt="Pixel"/>`,
+		},
+		{
+			name:    "data link",
+			content: `<a href="data:text/html,<script>alert(1)</script>">Open</a>`,
+			want:    `<a rel="noopener">Open</a>`,
+		},
+		{
+			name:    "javascript citation",
+			content: `<blockquote cite="javascript:alert(1)">Quote</blockquote>`,
+			want:    `<blockquote>Quote</blockquote>`,
+		},
+		{
+			name:    "mailto link kept",
+			content: `<a href="mailto:editor@example.com">Write</a>`,
+			want:    `<a href="mailto:editor@example.com" rel="noopener">Write</a>`,
+		},
+
+		// Event handlers and other attributes
+		{
+			name:    "onclick removed",
+			content: `<p onclick="alert(1)">Text</p>`,
+			want:    `<p>Text</p>`,
+		},
+		{
+			name:    "onerror removed, image kept",
+			content: `<img src="https://example.com/a.png" onerror="alert(1)" alt="A">`,
+			want:    `<img src="https://example.com/a.png" alt="A"/>`,
+		},
+		{
+			name:    "onmouseover removed from link",
+			content: `<a href="https://example.com" onmouseover="alert(1)" title="Home">Home</a>`,
+			want:    `<a href="https://example.com" title="Home" rel="noopener">Home</a>`,
+		},
+		{
+			name:    "style and class removed",
+			content: `<span style="color: red" class="warning" id="x">Text</span>`,
+			want:    `<span>Text</span>`,
+		},
+		{
+			name:    "target replaced by noopener",
+			content: `<a href="https://example.com" target="_blank" rel="opener">Home</a>`,
+			want:    `<a href="https://example.com" rel="noopener">Home</a>`,
+		},
+
+		// Relative URLs
+		{
+			name:    "relative link resolved",
+			content: `<a href="../about">About</a>`,
+			base:    base,
+			want:    `<a href="https://example.com/about" rel="noopener">About</a>`,
+		},
+		{
+			name:    "root-relative image resolved",
+			content: `<img src="/images/a.png">`,
+			base:    base,
+			want:    `<img src="https://example.com/images/a.png"/>`,
+		},
+		{
+			name:    "protocol-relative image resolved",
+			content: `<img src="//cdn.example.com/a.png">`,
+			base:    base,
+			want:    `<img src="https://cdn.example.com/a.png"/>`,
+		},
+		{
+			name:    "fragment resolved",
+			content: `<a href="#comments">Comments</a>`,
+			base:    base,
+			want:    `<a href="https://example.com/posts/1#comments" rel="noopener">Comments</a>`,
+		},
+		{
+			name:    "absolute link untouched",
+			content: `<a href="https://other.example/page">Other</a>`,
+			base:    base,
+			want:    `<a href="https://other.example/page" rel="noopener">Other</a>`,
+		},
+		{
+			name:    "relative link kept without base",
+			content: `<a href="/about">About</a>`,
+			want:    `<a href="/about" rel="noopener">About</a>`,
+		},
+		{
+			name:    "relative link kept with relative base",
+			content: `<a href="about">About</a>`,
+			base:    "/posts/1",
+			want:    `<a href="about" rel="noopener">About</a>`,
+		},
+
+		// Content passed through
+		{
+			name:    "allowed markup kept",
+			content: `<h2>Title</h2><p><strong>Bold</strong> and <em>italic</em></p><ul><li>One</li></ul>`,
+			want:    `<h2>Title</h2><p><strong>Bold</strong> and <em>italic</em></p><ul><li>One</li></ul>`,
+		},
+		{
+			name:    "text escaped",
+			content: `Fish &amp; chips <3`,
+			want:    `Fish &amp; chips &lt;3`,
+		},
+		{
+			name:    "blank content unchanged",
+			content: "  \n",
+			want:    "  \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Sanitize(tt.content, tt.base))
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "blocks on separate lines",
+			content: `<h1>Title</h1><p>First  paragraph</p><ul><li>One</li><li>Two</li></ul>`,
+			want:    "Title\nFirst paragraph\nOne\nTwo",
+		},
+		{
+			name:    "inline elements joined",
+			content: `<p><strong>Bold</strong> and <a href="https://example.com">link</a></p>`,
+			want:    "Bold and link",
+		},
+		{
+			name:    "script and style contribute no text",
+			content: `<style>p { color: red }</style><p>Text</p><script>alert(1)</script>`,
+			want:    "Text",
+		},
+		{
+			name:    "entities decoded",
+			content: `Fish &amp; chips`,
+			want:    "Fish & chips",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PlainText(tt.content))
+		})
+	}
+}
+
+func TestSanitizeItems(t *testing.T) {
+	const hostile = `<p>Hi</p><script>alert(1)</script>`
+
+	tests := []struct {
+		name        string
+		item        Item
+		wantContent string
+		wantSummary string
+	}{
+		{
+			name:        "html content and summary sanitized",
+			item:        Item{Content: hostile, ContentType: ContentTypeHTML, Summary: hostile},
+			wantContent: `<p>Hi</p>`,
+			wantSummary: `<p>Hi</p>`,
+		},
+		{
+			name:        "text content kept, html summary sanitized",
+			item:        Item{Content: hostile, ContentType: ContentTypeText, Summary: hostile},
+			wantContent: hostile,
+			wantSummary: `<p>Hi</p>`,
+		},
+		{
+			name:        "text summary escaped",
+			item:        Item{Content: hostile, ContentType: ContentTypeText, Summary: "1 < 2 & <b>", summaryType: ContentTypeText},
+			wantContent: hostile,
+			wantSummary: `1 &lt; 2 &amp; &lt;b&gt;`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := &FeedInfo{Items: []Item{tt.item}}
+			sanitizeItems(feed)
+			assert.Equal(t, tt.wantContent, feed.Items[0].Content)
+			assert.Equal(t, hostile, feed.Items[0].RawContent)
+			assert.Equal(t, tt.wantSummary, feed.Items[0].Summary)
+		})
+	}
+}
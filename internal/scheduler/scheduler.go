@@ -0,0 +1,233 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"rss-aggregator/clean-arch/adapter/storerepo"
+	"rss-aggregator/clean-arch/entity"
+	"rss-aggregator/internal/backoff"
+	"rss-aggregator/internal/database"
+)
+
+const (
+	// DefaultInterval is how often each feed is polled when not configured
+	DefaultInterval = 30 * time.Minute
+	// DefaultWorkers is the number of concurrent fetches when not configured
+	DefaultWorkers = 4
+	// DefaultMaxFailures is the number of failed fetches in a row that
+	// disables a feed when not configured
+	DefaultMaxFailures = 10
+	// defaultPollInterval is how often the scheduler looks for due feeds
+	defaultPollInterval = time.Minute
+	// defaultBatchSize caps the number of due feeds picked per poll
+	defaultBatchSize = 100
+	// maxDelay caps how far publisher headers may push the next fetch
+	maxDelay = 24 * time.Hour
+)
+
+// Refresher fetches a feed, stores its new articles and records the fetch in
+// the health of the feed
+type Refresher interface {
+	RefreshFeed(ctx context.Context, feedID int) (*entity.ParsedFeed, error)
+}
+
+// Config holds scheduler settings
+type Config struct {
+	// Interval is the time between two fetches of the same feed
+	Interval time.Duration
+	// Workers bounds the number of feeds fetched concurrently
+	Workers int
+	// PollInterval is how often the database is checked for due feeds
+	PollInterval time.Duration
+	// BatchSize caps the number of due feeds picked per poll
+	BatchSize int
+	// MaxFailures is the number of failed fetches in a row that disables a feed
+	MaxFailures int
+}
+
+// Scheduler periodically refreshes every feed with a bounded worker pool
+type Scheduler struct {
+	db        database.Store
+	refresher Refresher
+	config    Config
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	inFlight map[int]bool
+}
+
+// New creates a new scheduler, filling unset config values with defaults
+func New(db database.Store, refresher Refresher, config Config) *Scheduler {
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.Workers <= 0 {
+		config.Workers = DefaultWorkers
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = min(defaultPollInterval, config.Interval)
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.MaxFailures <= 0 {
+		config.MaxFailures = DefaultMaxFailures
+	}
+
+	return &Scheduler{
+		db:        db,
+		refresher: refresher,
+		config:    config,
+		inFlight:  make(map[int]bool),
+	}
+}
+
+// Start launches the dispatcher and the worker pool in the background
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	jobs := make(chan database.Feed)
+
+	// Fetches already handed to a worker run to completion after Stop,
+	// bounded by the parser's fetch timeout
+	workerCtx := context.WithoutCancel(ctx)
+	for i := 0; i < s.config.Workers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for feed := range jobs {
+				s.refresh(workerCtx, feed)
+			}
+		}()
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(jobs)
+		s.dispatch(ctx, jobs)
+	}()
+}
+
+// Stop stops dispatching and waits for in-flight fetches to finish
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+// dispatch hands due feeds to the workers until the context is cancelled
+func (s *Scheduler) dispatch(ctx context.Context, jobs chan<- database.Feed) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		feeds, err := s.db.GetDueFeeds(ctx, time.Now(), s.config.BatchSize)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("scheduler: failed to load due feeds: %v", err)
+		}
+
+		for _, feed := range feeds {
+			if !s.claim(feed.ID) {
+				continue // Still being fetched
+			}
+			select {
+			case jobs <- feed:
+			case <-ctx.Done():
+				s.release(feed.ID)
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh fetches a single feed and schedules its next fetch
+func (s *Scheduler) refresh(ctx context.Context, feed database.Feed) {
+	defer s.release(feed.ID)
+
+	parsedFeed, err := s.refresher.RefreshFeed(ctx, feed.ID)
+	if err != nil {
+		log.Printf("scheduler: failed to refresh feed %d (%s): %v", feed.ID, feed.URL, err)
+	}
+
+	// Reload the feed with the fetch recorded, unless it was deleted while
+	// being fetched
+	current, loadErr := s.db.GetFeedByID(ctx, feed.ID)
+	if loadErr != nil {
+		log.Printf("scheduler: failed to load feed %d: %v", feed.ID, loadErr)
+		return
+	}
+	if current == nil {
+		return
+	}
+	health := storerepo.ToEntityFeedHealth(current.Health)
+
+	health.ScheduleNext(time.Now(), s.policy(), requestedDelay(parsedFeed, err))
+	if health.DisabledAt != nil {
+		log.Printf("scheduler: disabled feed %d (%s) after %d failed fetches", feed.ID, feed.URL, health.ConsecutiveFailures)
+	}
+	if err := s.db.UpdateFeedHealth(ctx, storerepo.ToStoreFeedHealth(feed.ID, health)); err != nil {
+		log.Printf("scheduler: failed to record fetch status of feed %d: %v", feed.ID, err)
+	}
+}
+
+// policy returns the schedule of the feeds as set by the config. The pause
+// doubles with every failure in a row and is jittered, so feeds that failed
+// together are not fetched again all at once.
+func (s *Scheduler) policy() entity.FetchPolicy {
+	return entity.FetchPolicy{
+		Interval:    s.config.Interval,
+		MaxDelay:    maxDelay,
+		MaxFailures: s.config.MaxFailures,
+		Backoff:     backoff.Delay,
+	}
+}
+
+// requestedDelay returns the pause the publisher asked for with
+// Cache-Control: max-age or Retry-After, zero if none
+func requestedDelay(parsedFeed *entity.ParsedFeed, err error) time.Duration {
+	var httpErr *entity.HTTPError
+	switch {
+	case parsedFeed != nil:
+		return parsedFeed.MaxAge
+	case errors.As(err, &httpErr):
+		return httpErr.RetryAfter
+	default:
+		return 0
+	}
+}
+
+// claim marks a feed as in flight, reporting false if it already is
+func (s *Scheduler) claim(feedID int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.inFlight[feedID] {
+		return false
+	}
+	s.inFlight[feedID] = true
+	return true
+}
+
+// release clears the in-flight mark of a feed
+func (s *Scheduler) release(feedID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.inFlight, feedID)
+}
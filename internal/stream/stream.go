@@ -0,0 +1,88 @@
+package stream
+
+import "sync"
+
+// Hub tells subscribers that new articles were ingested. Notices carry no
+// articles: a subscriber reads what is new from the database, so a slow one
+// misses nothing and pending notices coalesce into one.
+type Hub struct {
+	mu            sync.Mutex
+	subscriptions map[*Subscription]struct{}
+	closed        bool
+}
+
+// Subscription receives the notices published to a hub until it or the hub
+// is closed
+type Subscription struct {
+	hub    *Hub
+	notify chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewHub creates a hub without subscribers
+func NewHub() *Hub {
+	return &Hub{subscriptions: make(map[*Subscription]struct{})}
+}
+
+// Subscribe registers a subscriber. After Close the subscription is closed
+// from the start.
+func (h *Hub) Subscribe() *Subscription {
+	subscription := &Subscription{
+		hub:    h,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		subscription.once.Do(func() { close(subscription.done) })
+		return subscription
+	}
+	h.subscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+// Publish notifies every subscriber without waiting for any of them
+func (h *Hub) Publish() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for subscription := range h.subscriptions {
+		select {
+		case subscription.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close closes every subscription and those made afterwards
+func (h *Hub) Close() {
+	h.mu.Lock()
+	subscriptions := h.subscriptions
+	h.subscriptions = make(map[*Subscription]struct{})
+	h.closed = true
+	h.mu.Unlock()
+
+	for subscription := range subscriptions {
+		subscription.once.Do(func() { close(subscription.done) })
+	}
+}
+
+// Notify is signalled when articles were published since it was last read
+func (s *Subscription) Notify() <-chan struct{} {
+	return s.notify
+}
+
+// Done is closed once the subscription or its hub is closed
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close unregisters the subscriber
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	delete(s.hub.subscriptions, s)
+	s.hub.mu.Unlock()
+	s.once.Do(func() { close(s.done) })
+}
@@ -0,0 +1,490 @@
+package syndication
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats a feed can be written in
+const (
+	FormatRSS  = "rss"
+	FormatAtom = "atom"
+	FormatJSON = "json"
+)
+
+// generator names the program in the written feeds
+const generator = "RSS Aggregator"
+
+// Feed is a feed to be written
+type Feed struct {
+	Title       string
+	Description string
+	// SelfURL is where the feed itself is served; it also identifies it
+	SelfURL string
+	// Updated is when the feed last changed, see LastUpdated
+	Updated time.Time
+	Items   []Item
+}
+
+// Item is an entry of a written feed
+type Item struct {
+	// ID identifies the item across versions of the feed. An absolute URL
+	// that is also Link marks a permalink.
+	ID    string
+	Link  string
+	Title string
+	// Content and Summary are HTML
+	Content    string
+	Summary    string
+	Published  *time.Time
+	Updated    *time.Time
+	Authors    []string
+	Categories []string
+	Enclosures []Enclosure
+	// Source names the feed the item was taken from
+	Source Source
+}
+
+// Enclosure is media attached to an item
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length int64
+}
+
+// Source is the feed an item comes from
+type Source struct {
+	Title string
+	URL   string
+}
+
+// ContentType returns the media type a feed is served with in the format,
+// or "" for an unknown format
+func ContentType(format string) string {
+	switch format {
+	case FormatRSS:
+		return "application/rss+xml; charset=utf-8"
+	case FormatAtom:
+		return "application/atom+xml; charset=utf-8"
+	case FormatJSON:
+		return "application/feed+json; charset=utf-8"
+	}
+	return ""
+}
+
+// LastUpdated returns the latest date among the items, the Unix epoch for a
+// feed without dates, so that an unchanged feed is written the same way
+func LastUpdated(items []Item) time.Time {
+	latest := time.Unix(0, 0).UTC()
+	for _, item := range items {
+		if date := item.date(); date != nil && date.After(latest) {
+			latest = date.UTC()
+		}
+	}
+	return latest
+}
+
+// ETag returns the entity tag of a written feed. A feed is written the same
+// way until its items change, so the hash of the body identifies the version.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ETagMatches reports whether an If-None-Match header lists the ETag,
+// comparing weakly as RFC 9110 requires for it
+func ETagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// Write writes a feed as RSS 2.0, Atom 1.0 or JSON Feed 1.1
+func Write(w io.Writer, format string, feed Feed) error {
+	switch format {
+	case FormatRSS:
+		return writeXML(w, toRSS(feed))
+	case FormatAtom:
+		return writeXML(w, toAtom(feed))
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(toJSONFeed(feed))
+	}
+	return fmt.Errorf("unknown feed format %q", format)
+}
+
+// writeXML writes an XML document with its declaration
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// date returns when the item last changed
+func (item Item) date() *time.Time {
+	if item.Updated != nil {
+		return item.Updated
+	}
+	return item.Published
+}
+
+// isPermalink reports whether the item ID is the URL of the item
+func (item Item) isPermalink() bool {
+	return item.ID != "" && item.ID == item.Link
+}
+
+// atomID returns the item ID as an IRI, which Atom requires
+func (item Item) atomID() string {
+	if parsed, err := url.Parse(item.ID); err == nil && parsed.Scheme != "" {
+		return item.ID
+	}
+	return "urn:rss-aggregator:guid:" + url.PathEscape(item.ID)
+}
+
+// RSS 2.0 with the Atom self link, content:encoded and dc:creator
+
+type rssDocument struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	DCNS      string     `xml:"xmlns:dc,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	SelfLink      atomLink  `xml:"atom:link"`
+	Generator     string    `xml:"generator"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string         `xml:"title,omitempty"`
+	Link        string         `xml:"link,omitempty"`
+	GUID        rssGUID        `xml:"guid"`
+	PubDate     string         `xml:"pubDate,omitempty"`
+	Description string         `xml:"description,omitempty"`
+	Content     string         `xml:"content:encoded,omitempty"`
+	Creators    []string       `xml:"dc:creator"`
+	Categories  []string       `xml:"category"`
+	Enclosures  []rssEnclosure `xml:"enclosure"`
+	Source      *rssSource     `xml:"source"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type rssSource struct {
+	URL   string `xml:"url,attr"`
+	Title string `xml:",chardata"`
+}
+
+func toRSS(feed Feed) rssDocument {
+	channel := rssChannel{
+		Title:         feed.Title,
+		Link:          feed.SelfURL,
+		Description:   feed.Description,
+		SelfLink:      atomLink{Href: feed.SelfURL, Rel: "self", Type: ContentType(FormatRSS)},
+		Generator:     generator,
+		LastBuildDate: feed.Updated.UTC().Format(time.RFC1123Z),
+		Items:         make([]rssItem, 0, len(feed.Items)),
+	}
+	if channel.Description == "" {
+		channel.Description = feed.Title
+	}
+
+	for _, item := range feed.Items {
+		converted := rssItem{
+			Title:      item.Title,
+			Link:       item.Link,
+			GUID:       rssGUID{IsPermaLink: item.isPermalink(), Value: item.ID},
+			Content:    item.Content,
+			Creators:   item.Authors,
+			Categories: item.Categories,
+		}
+		// The description carries the summary when there is one and the
+		// content otherwise, which readers without content:encoded show
+		converted.Description = item.Summary
+		if converted.Description == "" {
+			converted.Description = item.Content
+		}
+		if date := item.Published; date != nil || item.Updated != nil {
+			if date == nil {
+				date = item.Updated
+			}
+			converted.PubDate = date.UTC().Format(time.RFC1123Z)
+		}
+		for _, enclosure := range item.Enclosures {
+			converted.Enclosures = append(converted.Enclosures, rssEnclosure{
+				URL:    enclosure.URL,
+				Length: enclosure.Length,
+				Type:   mediaType(enclosure.Type),
+			})
+		}
+		if item.Source.URL != "" {
+			converted.Source = &rssSource{URL: item.Source.URL, Title: item.Source.Title}
+		}
+		channel.Items = append(channel.Items, converted)
+	}
+
+	return rssDocument{
+		Version:   "2.0",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		DCNS:      "http://purl.org/dc/elements/1.1/",
+		Channel:   channel,
+	}
+}
+
+// Atom 1.0
+
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"feed"`
+	NS        string      `xml:"xmlns,attr"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Subtitle  string      `xml:"subtitle,omitempty"`
+	Updated   string      `xml:"updated"`
+	Links     []atomLink  `xml:"link"`
+	Generator string      `xml:"generator"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      atomText       `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Links      []atomLink     `xml:"link"`
+	Authors    []atomPerson   `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary"`
+	Content    *atomText      `xml:"content"`
+	Source     *atomSource    `xml:"source"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomSource struct {
+	ID    string   `xml:"id"`
+	Title string   `xml:"title,omitempty"`
+	Link  atomLink `xml:"link"`
+}
+
+func toAtom(feed Feed) atomFeed {
+	converted := atomFeed{
+		NS:        "http://www.w3.org/2005/Atom",
+		ID:        feed.SelfURL,
+		Title:     feed.Title,
+		Subtitle:  feed.Description,
+		Updated:   feed.Updated.UTC().Format(time.RFC3339),
+		Links:     []atomLink{{Href: feed.SelfURL, Rel: "self", Type: ContentType(FormatAtom)}},
+		Generator: generator,
+		Entries:   make([]atomEntry, 0, len(feed.Items)),
+	}
+
+	for _, item := range feed.Items {
+		entry := atomEntry{
+			ID:    item.atomID(),
+			Title: atomText{Type: "text", Value: item.Title},
+		}
+		// Atom requires an update date; an item without dates takes the feed's
+		updated := feed.Updated
+		if date := item.date(); date != nil {
+			updated = *date
+		}
+		entry.Updated = updated.UTC().Format(time.RFC3339)
+		if item.Published != nil {
+			entry.Published = item.Published.UTC().Format(time.RFC3339)
+		}
+		if item.Link != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.Link, Rel: "alternate"})
+		}
+		for _, enclosure := range item.Enclosures {
+			entry.Links = append(entry.Links, atomLink{
+				Href:   enclosure.URL,
+				Rel:    "enclosure",
+				Type:   mediaType(enclosure.Type),
+				Length: enclosure.Length,
+			})
+		}
+		// Atom requires an author; an item without one is credited to its source
+		for _, author := range item.Authors {
+			entry.Authors = append(entry.Authors, atomPerson{Name: author})
+		}
+		if len(entry.Authors) == 0 {
+			entry.Authors = []atomPerson{{Name: sourceName(item.Source, feed.Title)}}
+		}
+		for _, category := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+		if item.Summary != "" {
+			entry.Summary = &atomText{Type: "html", Value: item.Summary}
+		}
+		// An entry without a link of its own must have content, if empty
+		if item.Content != "" || item.Link == "" {
+			entry.Content = &atomText{Type: "html", Value: item.Content}
+		}
+		if item.Source.URL != "" {
+			entry.Source = &atomSource{
+				ID:    item.Source.URL,
+				Title: item.Source.Title,
+				Link:  atomLink{Href: item.Source.URL, Rel: "self"},
+			}
+		}
+		converted.Entries = append(converted.Entries, entry)
+	}
+
+	return converted
+}
+
+// JSON Feed 1.1
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	FeedURL     string         `json:"feed_url"`
+	Description string         `json:"description,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string               `json:"id"`
+	URL           string               `json:"url,omitempty"`
+	Title         string               `json:"title,omitempty"`
+	ContentHTML   string               `json:"content_html"`
+	DatePublished string               `json:"date_published,omitempty"`
+	DateModified  string               `json:"date_modified,omitempty"`
+	Authors       []jsonFeedAuthor     `json:"authors,omitempty"`
+	Tags          []string             `json:"tags,omitempty"`
+	Attachments   []jsonFeedAttachment `json:"attachments,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedAttachment struct {
+	URL         string `json:"url"`
+	MimeType    string `json:"mime_type"`
+	SizeInBytes int64  `json:"size_in_bytes,omitempty"`
+}
+
+func toJSONFeed(feed Feed) jsonFeed {
+	converted := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		FeedURL:     feed.SelfURL,
+		Description: feed.Description,
+		Items:       make([]jsonFeedItem, 0, len(feed.Items)),
+	}
+
+	for _, item := range feed.Items {
+		entry := jsonFeedItem{
+			ID:          item.ID,
+			URL:         item.Link,
+			Title:       item.Title,
+			ContentHTML: item.Content,
+			Tags:        item.Categories,
+		}
+		// JSON Feed requires content; the summary stands in for missing content
+		if entry.ContentHTML == "" {
+			entry.ContentHTML = item.Summary
+		}
+		if item.Published != nil {
+			entry.DatePublished = item.Published.UTC().Format(time.RFC3339)
+		}
+		if item.Updated != nil {
+			entry.DateModified = item.Updated.UTC().Format(time.RFC3339)
+		}
+		for _, author := range item.Authors {
+			entry.Authors = append(entry.Authors, jsonFeedAuthor{Name: author})
+		}
+		for _, enclosure := range item.Enclosures {
+			entry.Attachments = append(entry.Attachments, jsonFeedAttachment{
+				URL:         enclosure.URL,
+				MimeType:    mediaType(enclosure.Type),
+				SizeInBytes: enclosure.Length,
+			})
+		}
+		converted.Items = append(converted.Items, entry)
+	}
+
+	return converted
+}
+
+// mediaType returns the type of an enclosure, which all formats require
+func mediaType(value string) string {
+	if value == "" {
+		return "application/octet-stream"
+	}
+	return value
+}
+
+// sourceName names the source of an item, falling back to its URL and then
+// to the given name
+func sourceName(source Source, fallback string) string {
+	if source.Title != "" {
+		return source.Title
+	}
+	if source.URL != "" {
+		return source.URL
+	}
+	return fallback
+}
+
+// ItemID returns an ID for an item without a link or GUID of its own
+func ItemID(articleID int) string {
+	return "urn:rss-aggregator:article:" + strconv.Itoa(articleID)
+}
@@ -0,0 +1,279 @@
+package syndication
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFeed returns a feed with a permalinked item carrying everything and
+// an item without a link, dates or authors
+func testFeed() Feed {
+	published := time.Date(2025, 12, 1, 10, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	updated := published.Add(2 * time.Hour)
+
+	items := []Item{
+		{
+			ID:         "https://example.com/posts/1",
+			Link:       "https://example.com/posts/1",
+			Title:      "First post",
+			Content:    "<p>Full <b>content</b></p>",
+			Summary:    "<p>Summary</p>",
+			Published:  &published,
+			Updated:    &updated,
+			Authors:    []string{"Alice", "Bob"},
+			Categories: []string{"go", "rss"},
+			Enclosures: []Enclosure{
+				{URL: "https://example.com/episode.mp3", Type: "audio/mpeg", Length: 1024},
+				{URL: "https://example.com/file"},
+			},
+			Source: Source{Title: "Example blog", URL: "https://example.com/feed.xml"},
+		},
+		{
+			ID:      "note-2",
+			Title:   "Note",
+			Summary: "<p>Only a summary</p>",
+		},
+	}
+
+	return Feed{
+		Title:       "All articles",
+		Description: "Articles of every feed",
+		SelfURL:     "https://aggregator.example/output/all.rss",
+		Updated:     LastUpdated(items),
+		Items:       items,
+	}
+}
+
+func TestWrite(t *testing.T) {
+	feed := testFeed()
+	updated := time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC)
+
+	for _, format := range []string{FormatRSS, FormatAtom, FormatJSON} {
+		t.Run("parsed as "+format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, format, feed))
+
+			parsed, err := gofeed.NewParser().ParseString(buf.String())
+			require.NoError(t, err)
+			assert.Equal(t, "All articles", parsed.Title)
+			require.Len(t, parsed.Items, 2)
+
+			first := parsed.Items[0]
+			assert.Equal(t, "First post", first.Title)
+			assert.Equal(t, "https://example.com/posts/1", first.Link)
+			assert.Equal(t, "https://example.com/posts/1", first.GUID)
+			assert.Equal(t, "<p>Full <b>content</b></p>", first.Content)
+			require.NotNil(t, first.PublishedParsed)
+			assert.True(t, first.PublishedParsed.Equal(updated.Add(-2*time.Hour)), first.PublishedParsed)
+			require.NotEmpty(t, first.Authors)
+			assert.Equal(t, "Alice", first.Authors[0].Name)
+			assert.Equal(t, []string{"go", "rss"}, first.Categories)
+			require.Len(t, first.Enclosures, 2)
+			assert.Equal(t, "https://example.com/episode.mp3", first.Enclosures[0].URL)
+			assert.Equal(t, "audio/mpeg", first.Enclosures[0].Type)
+			// Every format requires a media type
+			assert.Equal(t, "application/octet-stream", first.Enclosures[1].Type)
+
+			second := parsed.Items[1]
+			assert.Equal(t, "Note", second.Title)
+			assert.Empty(t, second.Link)
+		})
+	}
+
+	t.Run("rss", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatRSS, feed))
+		body := buf.String()
+
+		assert.Contains(t, body, `<guid isPermaLink="true">https://example.com/posts/1</guid>`)
+		assert.Contains(t, body, `<guid isPermaLink="false">note-2</guid>`)
+		assert.Contains(t, body, `<atom:link href="https://aggregator.example/output/all.rss" rel="self" type="application/rss+xml; charset=utf-8">`)
+		assert.Contains(t, body, `<lastBuildDate>Mon, 01 Dec 2025 09:00:00 +0000</lastBuildDate>`)
+		assert.Contains(t, body, `<source url="https://example.com/feed.xml">Example blog</source>`)
+		assert.Contains(t, body, `<dc:creator>Alice</dc:creator>`)
+		assert.Contains(t, body, `<dc:creator>Bob</dc:creator>`)
+		assert.Contains(t, body, `<enclosure url="https://example.com/episode.mp3" length="1024" type="audio/mpeg">`)
+		// Only the item with dates has a publication date
+		assert.Equal(t, 1, strings.Count(body, "<pubDate>"))
+
+		// The description carries the summary, the content when there is none
+		parsed, err := gofeed.NewParser().ParseString(body)
+		require.NoError(t, err)
+		assert.Equal(t, "<p>Summary</p>", parsed.Items[0].Description)
+		assert.Equal(t, "<p>Only a summary</p>", parsed.Items[1].Description)
+	})
+
+	t.Run("atom", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatAtom, feed))
+		body := buf.String()
+
+		assert.Contains(t, body, `<id>https://aggregator.example/output/all.rss</id>`)
+		assert.Contains(t, body, `<updated>2025-12-01T09:00:00Z</updated>`)
+		// IDs that are not IRIs are turned into URNs
+		assert.Contains(t, body, `<id>urn:rss-aggregator:guid:note-2</id>`)
+		assert.Contains(t, body, `<link href="https://example.com/episode.mp3" rel="enclosure" type="audio/mpeg" length="1024">`)
+		// An entry without a link has content, even if empty
+		assert.Contains(t, body, `<content type="html"></content>`)
+
+		parsed, err := gofeed.NewParser().ParseString(body)
+		require.NoError(t, err)
+		assert.Len(t, parsed.Items[0].Authors, 2)
+		assert.Equal(t, 1, strings.Count(body, "<published>"))
+		// An entry without authors is credited to the feed
+		require.Len(t, parsed.Items[1].Authors, 1)
+		assert.Equal(t, "All articles", parsed.Items[1].Authors[0].Name)
+		// An entry without dates takes the feed's
+		require.NotNil(t, parsed.Items[1].UpdatedParsed)
+		assert.True(t, parsed.Items[1].UpdatedParsed.Equal(updated))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, FormatJSON, feed))
+
+		var document map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+		assert.Equal(t, "https://jsonfeed.org/version/1.1", document["version"])
+		assert.Equal(t, "https://aggregator.example/output/all.rss", document["feed_url"])
+
+		items := document["items"].([]any)
+		first := items[0].(map[string]any)
+		assert.Equal(t, "2025-12-01T07:00:00Z", first["date_published"])
+		assert.Equal(t, "2025-12-01T09:00:00Z", first["date_modified"])
+		assert.Len(t, first["authors"], 2)
+		attachment := first["attachments"].([]any)[0].(map[string]any)
+		assert.Equal(t, float64(1024), attachment["size_in_bytes"])
+		// HTML is not escaped
+		assert.Contains(t, buf.String(), `"content_html": "<p>Full <b>content</b></p>"`)
+		// The summary stands in for missing content
+		second := items[1].(map[string]any)
+		assert.Equal(t, "<p>Only a summary</p>", second["content_html"])
+		assert.NotContains(t, second, "url")
+		assert.NotContains(t, second, "date_published")
+	})
+
+	t.Run("empty feed", func(t *testing.T) {
+		empty := Feed{Title: "Empty", SelfURL: "https://aggregator.example/output/all.json", Updated: LastUpdated(nil)}
+		for _, format := range []string{FormatRSS, FormatAtom, FormatJSON} {
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, format, empty), format)
+			parsed, err := gofeed.NewParser().ParseString(buf.String())
+			require.NoError(t, err, format)
+			assert.Empty(t, parsed.Items, format)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.Error(t, Write(&buf, "pdf", feed))
+		assert.Zero(t, buf.Len())
+	})
+}
+
+func TestContentType(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: FormatRSS, want: "application/rss+xml; charset=utf-8"},
+		{format: FormatAtom, want: "application/atom+xml; charset=utf-8"},
+		{format: FormatJSON, want: "application/feed+json; charset=utf-8"},
+		{format: "pdf", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.want, ContentType(tt.format))
+		})
+	}
+}
+
+func TestLastUpdated(t *testing.T) {
+	early := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2025, 6, 1, 12, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	latest := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		items []Item
+		want  time.Time
+	}{
+		{
+			name: "no items",
+			want: time.Unix(0, 0).UTC(),
+		},
+		{
+			name:  "items without dates",
+			items: []Item{{ID: "1"}, {ID: "2"}},
+			want:  time.Unix(0, 0).UTC(),
+		},
+		{
+			name:  "latest publication date, in UTC",
+			items: []Item{{Published: &early}, {Published: &late}},
+			want:  late.UTC(),
+		},
+		{
+			name:  "update date preferred to publication date",
+			items: []Item{{Published: &latest, Updated: &early}, {Published: &late}},
+			want:  late.UTC(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LastUpdated(tt.items)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, time.UTC, got.Location())
+		})
+	}
+}
+
+func TestETag(t *testing.T) {
+	etag := ETag([]byte("<rss/>"))
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, etag, ETag([]byte("<rss/>")))
+	assert.NotEqual(t, etag, ETag([]byte("<rss></rss>")))
+
+	// An unchanged feed is written, and tagged, the same way
+	var first, second bytes.Buffer
+	require.NoError(t, Write(&first, FormatAtom, testFeed()))
+	require.NoError(t, Write(&second, FormatAtom, testFeed()))
+	assert.Equal(t, ETag(first.Bytes()), ETag(second.Bytes()))
+}
+
+func TestETagMatches(t *testing.T) {
+	const etag = `"0123456789abcdef0123456789abcdef"`
+
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{name: "empty header", header: "", want: false},
+		{name: "same tag", header: etag, want: true},
+		{name: "other tag", header: `"other"`, want: false},
+		{name: "tag in a list", header: `"other", ` + etag, want: true},
+		{name: "weak tag", header: "W/" + etag, want: true},
+		{name: "weak tag in a list without spaces", header: `"other",W/` + etag, want: true},
+		{name: "any tag", header: "*", want: true},
+		{name: "unquoted tag", header: "0123456789abcdef0123456789abcdef", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ETagMatches(tt.header, etag))
+		})
+	}
+}
+
+func TestItemID(t *testing.T) {
+	assert.Equal(t, "urn:rss-aggregator:article:42", ItemID(42))
+}
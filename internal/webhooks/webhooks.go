@@ -0,0 +1,211 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"rss-aggregator/internal/backoff"
+	"rss-aggregator/internal/database"
+	"rss-aggregator/internal/worker"
+)
+
+const (
+	// DefaultWorkers is the number of deliveries sent concurrently when not configured
+	DefaultWorkers = 2
+	// DefaultMaxAttempts is the number of attempts after which a delivery is
+	// dead when not configured
+	DefaultMaxAttempts = 8
+	// DefaultRetryDelay is the pause after the first failed attempt when not configured
+	DefaultRetryDelay = 30 * time.Second
+	// defaultPollInterval is how often idle workers look for due deliveries
+	defaultPollInterval = 5 * time.Second
+	// defaultTimeout bounds a single attempt
+	defaultTimeout = 10 * time.Second
+	// maxRetryDelay caps the pause between two attempts
+	maxRetryDelay = 6 * time.Hour
+	// userAgent identifies the dispatcher to receivers
+	userAgent = "rss-aggregator-webhooks/1.0"
+)
+
+// Headers sent with every delivery
+const (
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body
+	// keyed with the webhook secret, see Sign
+	SignatureHeader = "X-Webhook-Signature"
+	// EventHeader carries the event of the payload, e.g. article.created
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader carries the delivery ID, the same across retries
+	DeliveryHeader = "X-Webhook-Delivery"
+)
+
+// Config holds dispatcher settings
+type Config struct {
+	// Workers bounds the number of deliveries sent concurrently
+	Workers int
+	// MaxAttempts is the number of failed attempts that makes a delivery dead
+	MaxAttempts int
+	// RetryDelay is the pause after the first failed attempt; it doubles
+	// with every further one
+	RetryDelay time.Duration
+	// PollInterval is how often idle workers check the outbox
+	PollInterval time.Duration
+	// Timeout bounds a single attempt
+	Timeout time.Duration
+	// Wake, when signalled, makes an idle worker check the outbox at once
+	Wake <-chan struct{}
+}
+
+// Dispatcher sends the deliveries queued in the webhook outbox, retrying
+// failed ones with backoff. The outbox lives in the database, so deliveries
+// outlive the process.
+type Dispatcher struct {
+	db      database.Store
+	client  *http.Client
+	config  Config
+	workers *worker.Pool
+}
+
+// New creates a dispatcher, filling unset config values with defaults
+func New(db database.Store, config Config) *Dispatcher {
+	if config.Workers <= 0 {
+		config.Workers = DefaultWorkers
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultRetryDelay
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = min(defaultPollInterval, config.RetryDelay)
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	d := &Dispatcher{
+		db:     db,
+		client: &http.Client{Timeout: config.Timeout},
+		config: config,
+	}
+	d.workers = worker.New("webhooks", d.claim, worker.Config{
+		Workers:      config.Workers,
+		PollInterval: config.PollInterval,
+		Wake:         config.Wake,
+	})
+	return d
+}
+
+// Sign returns the signature of a payload as sent in SignatureHeader
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Start puts the deliveries interrupted by a previous shutdown back in the
+// outbox and launches the workers in the background
+func (d *Dispatcher) Start() {
+	requeued, err := d.db.RequeueSendingWebhookDeliveries(context.Background())
+	if err != nil {
+		log.Printf("webhooks: failed to requeue interrupted deliveries: %v", err)
+	} else if requeued > 0 {
+		log.Printf("webhooks: requeued %d interrupted deliveries", requeued)
+	}
+
+	d.workers.Start()
+}
+
+// Stop stops taking deliveries and waits for the running ones to finish,
+// bounded by the client timeout
+func (d *Dispatcher) Stop() {
+	d.workers.Stop()
+}
+
+// claim takes the next due delivery
+func (d *Dispatcher) claim(ctx context.Context) (func(ctx context.Context), error) {
+	delivery, err := d.db.ClaimWebhookDelivery(ctx, time.Now())
+	if err != nil || delivery == nil {
+		return nil, err
+	}
+	return func(ctx context.Context) { d.deliver(ctx, *delivery) }, nil
+}
+
+// deliver makes one attempt at a delivery and records the outcome. A failed
+// delivery is retried later unless it has used up its attempts; so is one
+// whose webhook could not be loaded.
+func (d *Dispatcher) deliver(ctx context.Context, delivery database.WebhookDelivery) {
+	var status *int
+	webhook, err := d.db.GetWebhookByID(ctx, delivery.WebhookID)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("failed to load webhook %d: %w", delivery.WebhookID, err)
+	case webhook == nil:
+		// The webhook was deleted together with its deliveries
+		return
+	default:
+		status, err = d.send(ctx, webhook, delivery)
+	}
+	now := time.Now()
+	delivery.LastStatus = status
+	delivery.LastError = nil
+
+	switch {
+	case err == nil:
+		delivery.State = database.DeliveryDelivered
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= d.config.MaxAttempts:
+		log.Printf("webhooks: giving up delivery %d to webhook %d after %d attempts: %v", delivery.ID, delivery.WebhookID, delivery.Attempts, err)
+		message := err.Error()
+		delivery.State = database.DeliveryDead
+		delivery.LastError = &message
+	default:
+		message := err.Error()
+		delivery.State = database.DeliveryPending
+		delivery.LastError = &message
+		delivery.NextAttemptAt = now.Add(backoff.Delay(d.config.RetryDelay, maxRetryDelay, delivery.Attempts))
+	}
+
+	if err := d.db.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		log.Printf("webhooks: failed to record the outcome of delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// send posts the payload of a delivery to the webhook and returns the status
+// the receiver answered with, nil if it did not answer. Statuses other than
+// 2xx are errors.
+func (d *Dispatcher) send(ctx context.Context, webhook *database.Webhook, delivery database.WebhookDelivery) (*int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, strconv.Itoa(delivery.ID))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	status := resp.StatusCode
+	if status < 200 || status >= 300 {
+		return &status, fmt.Errorf("receiver answered %d %s", status, http.StatusText(status))
+	}
+	return &status, nil
+}
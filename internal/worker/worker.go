@@ -0,0 +1,95 @@
+// Package worker runs pools of workers that take work from a queue kept in
+// the database, so that the work outlives the process
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Claim takes the next piece of work from the queue and returns the function
+// that carries it out, or nil if there is nothing to do
+type Claim func(ctx context.Context) (func(ctx context.Context), error)
+
+// Config holds pool settings
+type Config struct {
+	// Workers bounds the number of pieces of work carried out concurrently
+	Workers int
+	// PollInterval is how often idle workers check the queue
+	PollInterval time.Duration
+	// Wake, when signalled, makes an idle worker check the queue at once
+	Wake <-chan struct{}
+}
+
+// Pool runs a fixed number of workers, each carrying out one piece of work
+// at a time and polling the queue when it runs dry
+type Pool struct {
+	// name prefixes the log messages of the pool
+	name   string
+	claim  Claim
+	config Config
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a pool taking work with claim
+func New(name string, claim Claim, config Config) *Pool {
+	return &Pool{
+		name:   name,
+		claim:  claim,
+		config: config,
+	}
+}
+
+// Start launches the workers in the background
+func (p *Pool) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	for i := 0; i < p.config.Workers; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.work(ctx)
+		}()
+	}
+}
+
+// Stop stops taking work and waits for the work in progress to finish
+func (p *Pool) Stop() {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+	p.wg.Wait()
+}
+
+// work carries out claimed work one piece at a time until the context is cancelled
+func (p *Pool) work(ctx context.Context) {
+	ticker := time.NewTicker(p.config.PollInterval)
+	defer ticker.Stop()
+
+	// Work already started runs to completion after Stop, bounded by the
+	// timeouts of the work itself
+	workCtx := context.WithoutCancel(ctx)
+	for {
+		run, err := p.claim(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("%s: failed to claim work: %v", p.name, err)
+		}
+		if run != nil {
+			run(workCtx)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.config.Wake:
+		case <-ticker.C:
+		}
+	}
+}
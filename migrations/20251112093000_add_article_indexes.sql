@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Даты публикации хранятся в UTC, чтобы сравнение строк совпадало с хронологией
+UPDATE articles
+SET publication_date = strftime('%Y-%m-%d %H:%M:%S+00:00', publication_date)
+WHERE publication_date IS NOT NULL;
+
+-- Лента новостей по всем фидам с курсорной пагинацией
+CREATE INDEX idx_articles_publication_date ON articles (publication_date DESC, id DESC);
+
+-- Статьи конкретных фидов
+CREATE INDEX idx_articles_feed_publication_date ON articles (feed_id, publication_date DESC, id DESC);
+
+-- Фильтр по статусу прочтения
+CREATE INDEX idx_articles_is_read_publication_date ON articles (is_read, publication_date DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_articles_is_read_publication_date;
+DROP INDEX IF EXISTS idx_articles_feed_publication_date;
+DROP INDEX IF EXISTS idx_articles_publication_date;
+-- +goose StatementEnd
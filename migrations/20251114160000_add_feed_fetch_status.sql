@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Состояние фонового обновления лент
+ALTER TABLE feeds ADD COLUMN last_fetched_at DATETIME;
+ALTER TABLE feeds ADD COLUMN last_error TEXT;
+ALTER TABLE feeds ADD COLUMN next_fetch_at DATETIME;
+
+-- Поиск лент, которые пора обновить
+CREATE INDEX idx_feeds_next_fetch_at ON feeds (next_fetch_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_feeds_next_fetch_at;
+ALTER TABLE feeds DROP COLUMN next_fetch_at;
+ALTER TABLE feeds DROP COLUMN last_error;
+ALTER TABLE feeds DROP COLUMN last_fetched_at;
+-- +goose StatementEnd
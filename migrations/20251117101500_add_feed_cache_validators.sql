@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- HTTP-валидаторы для условных запросов (If-None-Match / If-Modified-Since)
+ALTER TABLE feeds ADD COLUMN etag TEXT;
+ALTER TABLE feeds ADD COLUMN last_modified TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE feeds DROP COLUMN last_modified;
+ALTER TABLE feeds DROP COLUMN etag;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Идентичность статьи: GUID из ленты, а без него — хэш ссылки или содержимого.
+-- У статей, сохраненных до этой миграции, ключ пустой; при следующем обновлении
+-- ленты ключ присваивается статье с тем же заголовком.
+ALTER TABLE articles ADD COLUMN guid TEXT;
+ALTER TABLE articles ADD COLUMN link TEXT;
+ALTER TABLE articles ADD COLUMN guid_or_hash TEXT;
+
+CREATE UNIQUE INDEX idx_articles_feed_guid_or_hash ON articles (feed_id, guid_or_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_articles_feed_guid_or_hash;
+ALTER TABLE articles DROP COLUMN guid_or_hash;
+ALTER TABLE articles DROP COLUMN link;
+ALTER TABLE articles DROP COLUMN guid;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Метаданные статьи из ленты: авторы (по одному в строке), картинка и время
+-- последнего изменения записи у издателя.
+ALTER TABLE articles ADD COLUMN authors TEXT;
+ALTER TABLE articles ADD COLUMN image_url TEXT;
+ALTER TABLE articles ADD COLUMN updated_at DATETIME;
+
+-- Вложения статьи (аудио подкастов, изображения и т.п.)
+CREATE TABLE article_enclosures (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    article_id INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    type TEXT,
+    length INTEGER,
+    FOREIGN KEY (article_id) REFERENCES articles (id)
+);
+
+CREATE INDEX idx_article_enclosures_article_id ON article_enclosures (article_id);
+
+-- Рубрики статьи, заданные издателем
+CREATE TABLE article_tags (
+    article_id INTEGER NOT NULL,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (article_id, tag),
+    FOREIGN KEY (article_id) REFERENCES articles (id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS article_tags;
+DROP INDEX IF EXISTS idx_article_enclosures_article_id;
+DROP TABLE IF EXISTS article_enclosures;
+ALTER TABLE articles DROP COLUMN updated_at;
+ALTER TABLE articles DROP COLUMN image_url;
+ALTER TABLE articles DROP COLUMN authors;
+-- +goose StatementEnd
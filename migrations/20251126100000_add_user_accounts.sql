@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Учетные записи: пароль хранится как bcrypt-хэш
+ALTER TABLE users ADD COLUMN password_hash TEXT;
+ALTER TABLE users ADD COLUMN created_at DATETIME;
+
+-- API-токены; хранится только SHA-256 от токена
+CREATE TABLE api_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    token_hash TEXT UNIQUE NOT NULL,
+    created_at DATETIME NOT NULL,
+    last_used_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+CREATE INDEX idx_api_tokens_user_id ON api_tokens (user_id);
+
+-- Состояние статьи для каждого пользователя. Отсутствие строки означает,
+-- что статья не прочитана и не отмечена.
+CREATE TABLE user_articles (
+    user_id INTEGER NOT NULL,
+    article_id INTEGER NOT NULL,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    is_starred BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (user_id, article_id),
+    FOREIGN KEY (user_id) REFERENCES users (id),
+    FOREIGN KEY (article_id) REFERENCES articles (id)
+);
+
+CREATE INDEX idx_user_articles_article_id ON user_articles (article_id);
+CREATE INDEX idx_user_feeds_feed_id ON user_feeds (feed_id);
+
+-- Категории принадлежат пользователям; название уникально в пределах пользователя.
+-- У существующих категорий владельца нет, их получает первый зарегистрированный
+-- пользователь вместе с подписками на все ленты и состоянием articles.is_read.
+CREATE TABLE categories_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER,
+    name TEXT NOT NULL,
+    UNIQUE (user_id, name),
+    FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+INSERT INTO categories_new (id, name) SELECT id, name FROM categories;
+DROP TABLE categories;
+ALTER TABLE categories_new RENAME TO categories;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TABLE categories_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT UNIQUE NOT NULL
+);
+
+INSERT OR IGNORE INTO categories_old (id, name) SELECT id, name FROM categories;
+DROP TABLE categories;
+ALTER TABLE categories_old RENAME TO categories;
+
+DROP INDEX IF EXISTS idx_user_feeds_feed_id;
+DROP INDEX IF EXISTS idx_user_articles_article_id;
+DROP TABLE IF EXISTS user_articles;
+DROP INDEX IF EXISTS idx_api_tokens_user_id;
+DROP TABLE IF EXISTS api_tokens;
+ALTER TABLE users DROP COLUMN created_at;
+ALTER TABLE users DROP COLUMN password_hash;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Очередь фоновых подписок на ленты (POST /feeds?async=true). Задача проходит
+-- состояния queued -> running -> succeeded или failed; задачи, оставшиеся
+-- в running после остановки сервера, при запуске возвращаются в очередь
+CREATE TABLE feed_jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    state TEXT NOT NULL DEFAULT 'queued',
+    error TEXT,
+    feed_id INTEGER,
+    created_at DATETIME NOT NULL,
+    started_at DATETIME,
+    finished_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users (id),
+    FOREIGN KEY (feed_id) REFERENCES feeds (id)
+);
+
+CREATE INDEX idx_feed_jobs_state ON feed_jobs (state, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE feed_jobs;
+-- +goose StatementEnd
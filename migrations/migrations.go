@@ -0,0 +1,15 @@
+// Package migrations embeds the SQL migrations of the database schema, so the
+// server and the tests apply the same schema without reading files from disk.
+package migrations
+
+import "embed"
+
+// SQLite holds the goose-formatted SQLite migrations, named <version>_<name>.sql
+//
+//go:embed *.sql
+var SQLite embed.FS
+
+// Postgres holds the PostgreSQL migrations in the postgres directory
+//
+//go:embed postgres/*.sql
+var Postgres embed.FS
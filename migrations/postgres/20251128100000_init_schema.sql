@@ -0,0 +1,137 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Схема PostgreSQL соответствует схеме SQLite после всех миграций до
+-- 20251126100000_add_user_accounts; дальнейшие изменения схемы добавляются
+-- в обе папки миграций.
+
+-- Пользователи; пароль хранится как bcrypt-хэш
+CREATE TABLE users (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    email TEXT UNIQUE NOT NULL,
+    password_hash TEXT,
+    created_at TIMESTAMPTZ
+);
+
+-- API-токены; хранится только SHA-256 от токена
+CREATE TABLE api_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id),
+    token_hash TEXT UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    last_used_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_api_tokens_user_id ON api_tokens (user_id);
+
+-- Категории принадлежат пользователям; название уникально в пределах пользователя
+CREATE TABLE categories (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users (id),
+    name TEXT NOT NULL,
+    UNIQUE (user_id, name)
+);
+
+-- Ленты вместе с состоянием фонового обновления и HTTP-валидаторами
+CREATE TABLE feeds (
+    id SERIAL PRIMARY KEY,
+    url TEXT UNIQUE NOT NULL,
+    title TEXT,
+    description TEXT,
+    last_fetched_at TIMESTAMPTZ,
+    last_error TEXT,
+    next_fetch_at TIMESTAMPTZ,
+    etag TEXT,
+    last_modified TEXT
+);
+
+CREATE INDEX idx_feeds_next_fetch_at ON feeds (next_fetch_at);
+
+-- Статьи. guid_or_hash — GUID из ленты, а без него хэш ссылки или содержимого.
+-- is_read хранит статус прочтения до появления учетных записей, его
+-- наследует первый пользователь, как и в SQLite.
+-- search — полнотекстовый индекс: слова заголовка весят больше слов текста.
+CREATE TABLE articles (
+    id SERIAL PRIMARY KEY,
+    feed_id INTEGER NOT NULL REFERENCES feeds (id),
+    guid TEXT,
+    link TEXT,
+    guid_or_hash TEXT,
+    title TEXT NOT NULL,
+    content TEXT,
+    publication_date TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    authors TEXT,
+    image_url TEXT,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    search TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', title), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(content, '')), 'B')
+    ) STORED
+);
+
+CREATE UNIQUE INDEX idx_articles_feed_guid_or_hash ON articles (feed_id, guid_or_hash);
+CREATE INDEX idx_articles_publication_date ON articles (publication_date DESC NULLS LAST, id DESC);
+CREATE INDEX idx_articles_feed_publication_date ON articles (feed_id, publication_date DESC NULLS LAST, id DESC);
+CREATE INDEX idx_articles_search ON articles USING GIN (search);
+
+-- Вложения статьи (аудио подкастов, изображения и т.п.)
+CREATE TABLE article_enclosures (
+    id SERIAL PRIMARY KEY,
+    article_id INTEGER NOT NULL REFERENCES articles (id),
+    url TEXT NOT NULL,
+    type TEXT,
+    length BIGINT
+);
+
+CREATE INDEX idx_article_enclosures_article_id ON article_enclosures (article_id);
+
+-- Рубрики статьи, заданные издателем
+CREATE TABLE article_tags (
+    article_id INTEGER NOT NULL REFERENCES articles (id),
+    tag TEXT NOT NULL,
+    PRIMARY KEY (article_id, tag)
+);
+
+-- Подписки пользователей на ленты
+CREATE TABLE user_feeds (
+    user_id INTEGER REFERENCES users (id),
+    feed_id INTEGER REFERENCES feeds (id),
+    PRIMARY KEY (user_id, feed_id)
+);
+
+CREATE INDEX idx_user_feeds_feed_id ON user_feeds (feed_id);
+
+-- Ленты в категориях
+CREATE TABLE feed_categories (
+    feed_id INTEGER REFERENCES feeds (id),
+    category_id INTEGER REFERENCES categories (id),
+    PRIMARY KEY (feed_id, category_id)
+);
+
+-- Состояние статьи для каждого пользователя. Отсутствие строки означает,
+-- что статья не прочитана и не отмечена.
+CREATE TABLE user_articles (
+    user_id INTEGER NOT NULL REFERENCES users (id),
+    article_id INTEGER NOT NULL REFERENCES articles (id),
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    is_starred BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (user_id, article_id)
+);
+
+CREATE INDEX idx_user_articles_article_id ON user_articles (article_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_articles;
+DROP TABLE IF EXISTS feed_categories;
+DROP TABLE IF EXISTS user_feeds;
+DROP TABLE IF EXISTS article_tags;
+DROP TABLE IF EXISTS article_enclosures;
+DROP TABLE IF EXISTS articles;
+DROP TABLE IF EXISTS feeds;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS api_tokens;
+DROP TABLE IF EXISTS users;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Формат источника ленты (rss, atom или json) и его версия
+ALTER TABLE feeds ADD COLUMN format TEXT;
+ALTER TABLE feeds ADD COLUMN format_version TEXT;
+
+-- Данные статьи, зависящие от формата: дата публикации, указанная издателем
+-- (publication_date без нее берется из даты изменения), разметка content
+-- (html, xhtml или text), краткое описание рядом с полным текстом, баннер
+-- и расширения JSON Feed (объект JSON с полями, начинающимися с "_")
+ALTER TABLE articles ADD COLUMN published_at TIMESTAMPTZ;
+ALTER TABLE articles ADD COLUMN content_type TEXT;
+ALTER TABLE articles ADD COLUMN summary TEXT;
+ALTER TABLE articles ADD COLUMN banner_image TEXT;
+ALTER TABLE articles ADD COLUMN extensions TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE articles DROP COLUMN extensions;
+ALTER TABLE articles DROP COLUMN banner_image;
+ALTER TABLE articles DROP COLUMN summary;
+ALTER TABLE articles DROP COLUMN content_type;
+ALTER TABLE articles DROP COLUMN published_at;
+ALTER TABLE feeds DROP COLUMN format_version;
+ALTER TABLE feeds DROP COLUMN format;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Текст статьи в том виде, в котором его прислала лента. В content хранится
+-- очищенный HTML. Сохраненные ранее статьи очищаются при следующем обновлении
+-- ленты, если она их еще содержит, а до тех пор их исходный текст совпадает с content
+ALTER TABLE articles ADD COLUMN raw_content TEXT;
+UPDATE articles SET raw_content = content;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE articles DROP COLUMN raw_content;
+-- +goose StatementEnd
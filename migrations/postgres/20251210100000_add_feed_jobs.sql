@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Очередь фоновых подписок на ленты (POST /feeds?async=true). Задача проходит
+-- состояния queued -> running -> succeeded или failed; задачи, оставшиеся
+-- в running после остановки сервера, при запуске возвращаются в очередь
+CREATE TABLE feed_jobs (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id),
+    url TEXT NOT NULL,
+    state TEXT NOT NULL DEFAULT 'queued',
+    error TEXT,
+    feed_id INTEGER REFERENCES feeds (id),
+    created_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_feed_jobs_state ON feed_jobs (state, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE feed_jobs;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Здоровье ленты: число неудачных загрузок подряд, HTTP-статус и ошибка
+-- разбора последней загрузки, время последней успешной загрузки. Лента,
+-- у которой слишком много неудач подряд, отключается (disabled_at) и больше
+-- не обновляется, пока ее не включат вручную
+ALTER TABLE feeds ADD COLUMN consecutive_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE feeds ADD COLUMN last_http_status INTEGER;
+ALTER TABLE feeds ADD COLUMN last_parse_error TEXT;
+ALTER TABLE feeds ADD COLUMN last_success_at TIMESTAMPTZ;
+ALTER TABLE feeds ADD COLUMN disabled_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE feeds DROP COLUMN disabled_at;
+ALTER TABLE feeds DROP COLUMN last_success_at;
+ALTER TABLE feeds DROP COLUMN last_parse_error;
+ALTER TABLE feeds DROP COLUMN last_http_status;
+ALTER TABLE feeds DROP COLUMN consecutive_failures;
+-- +goose StatementEnd
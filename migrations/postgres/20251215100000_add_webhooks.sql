@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Подписки на вебхуки. Новые статьи лент пользователя отправляются на url,
+-- подписанные ключом secret; feed_id и category_id, если заданы, оставляют
+-- только статьи этой ленты или лент этой категории
+CREATE TABLE webhooks (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id),
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    feed_id INTEGER REFERENCES feeds (id),
+    category_id INTEGER REFERENCES categories (id),
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_webhooks_user_id ON webhooks (user_id);
+
+-- Очередь отправок вебхуков (outbox). Отправки создаются в той же транзакции,
+-- что и статьи, и проходят состояния pending -> sending -> delivered; после
+-- неудачи отправка возвращается в pending до next_attempt_at, а исчерпав
+-- попытки, остается в состоянии dead
+CREATE TABLE webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks (id),
+    event TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    state TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_status INTEGER,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (state, next_attempt_at);
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
+-- +goose StatementEnd
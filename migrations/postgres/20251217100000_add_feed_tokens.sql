@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Токены лент: открывают только выходные ленты /output и передаются в URL,
+-- поэтому отделены от API-токенов. У пользователя не больше одного токена;
+-- хранится только SHA-256 от него
+CREATE TABLE feed_tokens (
+    user_id INTEGER PRIMARY KEY REFERENCES users (id),
+    token_hash TEXT UNIQUE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE feed_tokens;
+-- +goose StatementEnd